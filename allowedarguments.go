@@ -0,0 +1,248 @@
+package glesys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ServerAllowedArguments are the allowed values of the server parameters per
+// platform, e.g. "KVM" or "VMware".
+type ServerAllowedArguments map[string]PlatformAllowedArguments
+
+// PlatformAllowedArguments are the allowed values of the server parameters
+// on a platform.
+type PlatformAllowedArguments struct {
+	DataCenter AllowedStrings `json:"datacenter"`
+	CPU        AllowedInts    `json:"cpucores"`
+	Memory     AllowedInts    `json:"memorysize"`
+	Storage    AllowedInts    `json:"disksize"`
+	Bandwidth  AllowedInts    `json:"bandwidth"`
+	Template   AllowedStrings `json:"template"`
+}
+
+// AllowedStrings are the allowed values of a string parameter.
+type AllowedStrings struct {
+	Text string   `json:"text"`
+	List []string `json:"list"`
+}
+
+// Allows reports whether value is allowed. All values are allowed if the
+// list is empty.
+func (a AllowedStrings) Allows(value string) bool {
+	if len(a.List) == 0 {
+		return true
+	}
+	for _, allowed := range a.List {
+		if strings.EqualFold(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedInts are the allowed values of a numeric parameter.
+type AllowedInts struct {
+	Text string `json:"text"`
+	Unit string `json:"unit"`
+	List []int  `json:"list"`
+}
+
+// Allows reports whether value is allowed. All values are allowed if the
+// list is empty.
+func (a AllowedInts) Allows(value int) bool {
+	if len(a.List) == 0 {
+		return true
+	}
+	for _, allowed := range a.List {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalJSON accepts values given as numbers or numeric strings.
+func (a *AllowedInts) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Text string        `json:"text"`
+		Unit string        `json:"unit"`
+		List []json.Number `json:"list"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	a.Text, a.Unit, a.List = raw.Text, raw.Unit, nil
+	for _, number := range raw.List {
+		value, err := strconv.Atoi(number.String())
+		if err != nil {
+			return fmt.Errorf("glesys: invalid allowed value %q: %w", number, err)
+		}
+		a.List = append(a.List, value)
+	}
+	return nil
+}
+
+// Platform returns the allowed arguments of the platform, which is matched
+// case insensitively.
+func (a ServerAllowedArguments) Platform(platform string) (PlatformAllowedArguments, bool) {
+	for name, arguments := range a {
+		if strings.EqualFold(name, platform) {
+			return arguments, true
+		}
+	}
+	return PlatformAllowedArguments{}, false
+}
+
+// ValidateCreate checks the parameters against the allowed arguments of
+// their platform. A *ParamsError is returned if the parameters are invalid.
+func (a ServerAllowedArguments) ValidateCreate(params CreateServerParams) error {
+	arguments, ok := a.Platform(params.Platform)
+	if !ok {
+		return paramsError([]FieldError{{"Platform", fmt.Sprintf("%q is not a valid platform", params.Platform)}})
+	}
+
+	var errs []FieldError
+	errs = checkString(errs, "DataCenter", params.DataCenter, arguments.DataCenter)
+	errs = checkString(errs, "Template", params.Template, arguments.Template)
+	errs = checkInt(errs, "CPU", params.CPU, arguments.CPU)
+	errs = checkInt(errs, "Memory", params.Memory, arguments.Memory)
+	errs = checkInt(errs, "Storage", params.Storage, arguments.Storage)
+	errs = checkInt(errs, "Bandwidth", params.Bandwidth, arguments.Bandwidth)
+	return paramsError(errs)
+}
+
+// ValidateEdit checks the parameters against the allowed arguments of the
+// platform of the server. Fields which are not set are not checked. A
+// *ParamsError is returned if the parameters are invalid.
+func (a ServerAllowedArguments) ValidateEdit(platform string, params EditServerParams) error {
+	arguments, ok := a.Platform(platform)
+	if !ok {
+		return paramsError([]FieldError{{"Platform", fmt.Sprintf("%q is not a valid platform", platform)}})
+	}
+
+	var errs []FieldError
+	if params.CPU != 0 {
+		errs = checkInt(errs, "CPU", params.CPU, arguments.CPU)
+	}
+	if params.Memory != 0 {
+		errs = checkInt(errs, "Memory", params.Memory, arguments.Memory)
+	}
+	if params.Storage != 0 {
+		errs = checkInt(errs, "Storage", params.Storage, arguments.Storage)
+	}
+	if params.Bandwidth != 0 {
+		errs = checkInt(errs, "Bandwidth", params.Bandwidth, arguments.Bandwidth)
+	}
+	return paramsError(errs)
+}
+
+func checkString(errs []FieldError, field, value string, allowed AllowedStrings) []FieldError {
+	if allowed.Allows(value) {
+		return errs
+	}
+	return append(errs, FieldError{field, fmt.Sprintf("%q is not one of %s", value, strings.Join(allowed.List, ", "))})
+}
+
+func checkInt(errs []FieldError, field string, value int, allowed AllowedInts) []FieldError {
+	if allowed.Allows(value) {
+		return errs
+	}
+	values := make([]string, len(allowed.List))
+	for i, v := range allowed.List {
+		values[i] = strconv.Itoa(v)
+	}
+	return append(errs, FieldError{field, fmt.Sprintf("%d is not one of %s", value, strings.Join(values, ", "))})
+}
+
+// WithParamsValidation makes the client validate the parameters of
+// Servers.Create and Servers.Edit against the allowed arguments before they
+// are sent, which returns a *ParamsError with an error per invalid field
+// instead of an error from the API. The allowed arguments are fetched when
+// needed and cached for ttl, or an hour if ttl is zero. Validating the
+// parameters of Edit also fetches the details of the server.
+func WithParamsValidation(ttl time.Duration) Option {
+	return func(o *options) error {
+		if ttl < 0 {
+			return fmt.Errorf("glesys: ttl must not be negative")
+		}
+		if ttl == 0 {
+			ttl = time.Hour
+		}
+		o.paramsValidation = ttl
+		return nil
+	}
+}
+
+// allowedArgumentsCache caches the allowed arguments for validating the
+// parameters of servers. The arguments are cached per project, since calls
+// may be made for other projects than that of the client using WithProject.
+type allowedArgumentsCache struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	projects map[string]cachedAllowedArguments
+}
+
+type cachedAllowedArguments struct {
+	arguments ServerAllowedArguments
+	expires   time.Time
+}
+
+// forProject returns an empty cache with the same ttl, since the allowed
+// arguments may differ between projects.
+func (c *allowedArgumentsCache) forProject() *allowedArgumentsCache {
+	if c == nil {
+		return nil
+	}
+	return &allowedArgumentsCache{ttl: c.ttl}
+}
+
+// get returns the allowed arguments of the project of ctx. The project of
+// the client is cached with the empty key.
+func (c *allowedArgumentsCache) get(ctx context.Context, s *ServerService) (ServerAllowedArguments, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	project, _ := ProjectFromContext(ctx)
+	if cached, ok := c.projects[project]; ok && time.Now().Before(cached.expires) {
+		return cached.arguments, nil
+	}
+	arguments, err := s.AllowedArguments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("glesys: fetching allowed arguments: %w", err)
+	}
+	if c.projects == nil {
+		c.projects = map[string]cachedAllowedArguments{}
+	}
+	c.projects[project] = cachedAllowedArguments{arguments: *arguments, expires: time.Now().Add(c.ttl)}
+	return *arguments, nil
+}
+
+func (c *allowedArgumentsCache) validateCreate(ctx context.Context, s *ServerService, params CreateServerParams) error {
+	arguments, err := c.get(ctx, s)
+	if err != nil {
+		return err
+	}
+	return arguments.ValidateCreate(params)
+}
+
+func (c *allowedArgumentsCache) validateEdit(ctx context.Context, s *ServerService, serverID string, params EditServerParams) error {
+	if params.CPU == 0 && params.Memory == 0 && params.Storage == 0 && params.Bandwidth == 0 {
+		return nil
+	}
+	arguments, err := c.get(ctx, s)
+	if err != nil {
+		return err
+	}
+	server, err := s.Details(ctx, serverID)
+	if err != nil {
+		return err
+	}
+	return arguments.ValidateEdit(server.Platform, params)
+}
@@ -0,0 +1,134 @@
+package glesys
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const allowedArgumentsBody = `{"response": {"argumentslist": {
+	"KVM": {
+		"datacenter": {"text": "Datacenter", "list": ["Falkenberg", "Stockholm"]},
+		"cpucores": {"text": "CPU cores", "unit": "cores", "list": [1, 2, 4]},
+		"memorysize": {"text": "Memory", "unit": "MB", "list": ["1024", "2048"]},
+		"disksize": {"text": "Disk", "unit": "GB", "list": [20, 50]},
+		"bandwidth": {"text": "Bandwidth", "unit": "Mbit/s", "list": [100]}
+	}
+}}}`
+
+func TestServersAllowedArguments(t *testing.T) {
+	c := &mockClient{body: allowedArgumentsBody}
+	s := ServerService{client: c}
+
+	arguments, err := s.AllowedArguments(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "POST", c.lastMethod, "method used is correct")
+	assert.Equal(t, "server/allowedarguments", c.lastPath, "path used is correct")
+
+	kvm, ok := arguments.Platform("kvm")
+	assert.True(t, ok, "platform is matched case insensitively")
+	assert.Equal(t, []string{"Falkenberg", "Stockholm"}, kvm.DataCenter.List)
+	assert.Equal(t, []int{1024, 2048}, kvm.Memory.List, "numeric strings are decoded")
+	assert.Equal(t, "GB", kvm.Storage.Unit)
+	assert.True(t, kvm.Template.Allows("Debian 12"), "empty list allows all values")
+}
+
+func TestServerAllowedArgumentsValidate(t *testing.T) {
+	c := &mockClient{body: allowedArgumentsBody}
+	arguments, _ := (&ServerService{client: c}).AllowedArguments(context.Background())
+
+	params := CreateServerParams{Platform: "KVM", DataCenter: "Oslo", CPU: 2, Memory: 4096, Storage: 20, Bandwidth: 100}
+	err := arguments.ValidateCreate(params)
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.EqualError(t, err, `glesys: invalid parameters: DataCenter: "Oslo" is not one of Falkenberg, Stockholm; Memory: 4096 is not one of 1024, 2048`)
+
+	params.DataCenter, params.Memory = "stockholm", 2048
+	assert.NoError(t, arguments.ValidateCreate(params))
+
+	params.Platform = "VMware"
+	err = arguments.ValidateCreate(params)
+	assert.EqualError(t, err, `glesys: invalid parameters: Platform: "VMware" is not a valid platform`)
+
+	assert.NoError(t, arguments.ValidateEdit("KVM", EditServerParams{CPU: 4}), "unset fields are not checked")
+	err = arguments.ValidateEdit("KVM", EditServerParams{CPU: 3, Description: "web"})
+	assert.EqualError(t, err, "glesys: invalid parameters: CPU: 3 is not one of 1, 2, 4")
+}
+
+func TestServersCreateWithParamsValidation(t *testing.T) {
+	c := &mockClient{body: allowedArgumentsBody}
+	s := ServerService{client: c, validator: &allowedArgumentsCache{ttl: time.Hour}}
+
+	params := CreateServerParams{Platform: "KVM", DataCenter: "Falkenberg", CPU: 3, Memory: 1024, Storage: 20, Bandwidth: 100}
+	_, err := s.Create(context.Background(), params)
+	var paramsErr *ParamsError
+	assert.ErrorAs(t, err, &paramsErr)
+	_, ok := paramsErr.Field("CPU")
+	assert.True(t, ok, "cpu is invalid")
+	assert.Equal(t, "server/allowedarguments", c.lastPath, "server is not created")
+
+	c.body = `{"response": {}}`
+	params.CPU = 2
+	_, err = s.Create(context.Background(), params)
+	assert.NoError(t, err)
+	assert.Equal(t, "server/create", c.lastPath, "allowed arguments are cached")
+}
+
+func TestWithParamsValidation(t *testing.T) {
+	client, err := NewClientWithOptions("CL12345", "secret", WithParamsValidation(0))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, client.Servers.validator.ttl, "default ttl is used")
+
+	other := client.ForProject("CL67890", "other")
+	assert.NotSame(t, client.Servers.validator, other.Servers.validator, "cache is not shared between projects")
+
+	_, err = NewClientWithOptions("CL12345", "secret", WithParamsValidation(-time.Second))
+	assert.Error(t, err)
+}
+
+// projectClient returns the body of the project set by WithProject.
+type projectClient struct {
+	bodies map[string]string
+	calls  []string
+}
+
+func (c *projectClient) get(ctx context.Context, path string, v interface{}) error {
+	return c.post(ctx, path, v, nil)
+}
+
+func (c *projectClient) post(ctx context.Context, path string, v interface{}, params interface{}) error {
+	project, _ := ProjectFromContext(ctx)
+	c.calls = append(c.calls, project+" "+path)
+	body := `{"response": {}}`
+	if path == "server/allowedarguments" {
+		body = c.bodies[project]
+	}
+	return json.Unmarshal([]byte(body), v)
+}
+
+func TestServersParamsValidationPerProject(t *testing.T) {
+	c := &projectClient{bodies: map[string]string{
+		"":        allowedArgumentsBody,
+		"CL67890": `{"response": {"argumentslist": {"KVM": {"cpucores": {"list": [1, 2, 3]}}}}}`,
+	}}
+	s := ServerService{client: c, validator: &allowedArgumentsCache{ttl: time.Hour}}
+	other := WithProject(context.Background(), "CL67890", "other")
+
+	params := CreateServerParams{Platform: "KVM", DataCenter: "Falkenberg", CPU: 3, Memory: 1024, Storage: 20, Bandwidth: 100}
+	_, err := s.Create(context.Background(), params)
+	assert.ErrorIs(t, err, ErrValidation, "cpu is not allowed in the project of the client")
+
+	_, err = s.Create(other, params)
+	assert.NoError(t, err, "cpu is allowed in the other project")
+
+	_, err = s.Create(other, params)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		" server/allowedarguments",
+		"CL67890 server/allowedarguments",
+		"CL67890 server/create",
+		"CL67890 server/create",
+	}, c.calls, "allowed arguments are cached per project")
+}
@@ -0,0 +1,194 @@
+package glesys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of items processed at the same time
+// by Batch and ForEach unless BatchOptions.Concurrency is set.
+const DefaultBatchConcurrency = 4
+
+// ErrSkipped is the error of items which were not processed because the
+// batch was aborted, see BatchOptions.FailFast.
+var ErrSkipped = errors.New("glesys: skipped")
+
+// BatchOptions configures Batch and ForEach.
+type BatchOptions struct {
+	// Concurrency is the maximum number of items processed at the same time.
+	// Defaults to DefaultBatchConcurrency.
+	Concurrency int
+	// FailFast aborts the batch on the first error. The context passed to
+	// items in progress is canceled and the remaining items are skipped.
+	FailFast bool
+	// Progress is called after each processed or skipped item. Calls are
+	// never made concurrently.
+	Progress func(BatchProgress)
+}
+
+// BatchProgress describes the progress of a batch.
+type BatchProgress struct {
+	// Total is the number of items in the batch.
+	Total int
+	// Done is the number of items which have been processed or skipped.
+	Done int
+	// Failed is the number of items which have failed or been skipped.
+	Failed int
+}
+
+// BatchResult is the outcome of processing an item.
+type BatchResult[T, R any] struct {
+	// Index is the index of the item.
+	Index int
+	// Item is the processed item.
+	Item T
+	// Value is the value returned for the item.
+	Value R
+	// Err is the error returned for the item, ErrSkipped if the item was not
+	// processed because the batch was aborted, or the error of the context if
+	// it was done before the item was processed.
+	Err error
+}
+
+// BatchReport contains the results of a batch in the order of the items.
+type BatchReport[T, R any] struct {
+	Results []BatchResult[T, R]
+}
+
+// Succeeded returns the results of the items which succeeded.
+func (r *BatchReport[T, R]) Succeeded() []BatchResult[T, R] {
+	var results []BatchResult[T, R]
+	for _, result := range r.Results {
+		if result.Err == nil {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Failed returns the results of the items which failed or were skipped.
+func (r *BatchReport[T, R]) Failed() []BatchResult[T, R] {
+	var results []BatchResult[T, R]
+	for _, result := range r.Results {
+		if result.Err != nil {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Values returns the values of the items which succeeded.
+func (r *BatchReport[T, R]) Values() []R {
+	var values []R
+	for _, result := range r.Results {
+		if result.Err == nil {
+			values = append(values, result.Value)
+		}
+	}
+	return values
+}
+
+// Err returns the errors of the failed items joined, or nil if all items
+// succeeded. Skipped items are not included.
+func (r *BatchReport[T, R]) Err() error {
+	var errs []error
+	for _, result := range r.Results {
+		if result.Err != nil && result.Err != ErrSkipped {
+			errs = append(errs, fmt.Errorf("item %d: %w", result.Index, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Batch calls fn for each item with at most opts.Concurrency calls in
+// progress at the same time and reports the outcome for every item.
+//
+// API calls made by fn are throttled by the rate limiter of the client as
+// usual, so the concurrency only bounds the number of calls in flight.
+//
+//	report := glesys.Batch(ctx, ids, func(ctx context.Context, id string) (*glesys.ServerDetails, error) {
+//		return client.Servers.Details(ctx, id)
+//	}, glesys.BatchOptions{Concurrency: 8})
+func Batch[T, R any](ctx context.Context, items []T, fn func(context.Context, T) (R, error), opts BatchOptions) *BatchReport[T, R] {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	report := &BatchReport[T, R]{Results: make([]BatchResult[T, R], len(items))}
+	progress := BatchProgress{Total: len(items)}
+	var mu sync.Mutex
+	aborted := false
+
+	done := func(i int, value R, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		report.Results[i] = BatchResult[T, R]{Index: i, Item: items[i], Value: value, Err: err}
+		progress.Done++
+		if err != nil {
+			progress.Failed++
+			if opts.FailFast && !aborted {
+				aborted = true
+				cancel()
+			}
+		}
+		if opts.Progress != nil {
+			opts.Progress(progress)
+		}
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(items); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				value, err := fn(ctx, items[i])
+				done(i, value, err)
+			}
+		}()
+	}
+
+	// skip reports an item which is not processed because ctx is done.
+	skip := func(i int) {
+		mu.Lock()
+		err := ctx.Err()
+		if aborted {
+			err = ErrSkipped
+		}
+		mu.Unlock()
+
+		var zero R
+		done(i, zero, err)
+	}
+
+	for i := range items {
+		if ctx.Err() != nil {
+			skip(i)
+			continue
+		}
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			skip(i)
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	return report
+}
+
+// ForEach calls fn for each item like Batch, for functions without a value.
+func ForEach[T any](ctx context.Context, items []T, fn func(context.Context, T) error, opts BatchOptions) *BatchReport[T, struct{}] {
+	return Batch(ctx, items, func(ctx context.Context, item T) (struct{}, error) {
+		return struct{}{}, fn(ctx, item)
+	}, opts)
+}
@@ -0,0 +1,110 @@
+package glesys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	var inFlight, maxInFlight int32
+	var progress []BatchProgress
+	report := Batch(context.Background(), items, func(ctx context.Context, item int) (string, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		if item%4 == 0 {
+			return "", fmt.Errorf("failed %d", item)
+		}
+		return fmt.Sprintf("item-%d", item), nil
+	}, BatchOptions{Concurrency: 3, Progress: func(p BatchProgress) { progress = append(progress, p) }})
+
+	assert.LessOrEqual(t, maxInFlight, int32(3), "concurrency is bounded")
+	assert.Len(t, report.Results, 10)
+	assert.Equal(t, "item-1", report.Results[0].Value, "results are in the order of the items")
+	assert.Equal(t, 8, report.Results[7].Item)
+	assert.Len(t, report.Succeeded(), 8)
+	assert.Len(t, report.Failed(), 2)
+	assert.Equal(t, []string{"item-1", "item-2", "item-3", "item-5", "item-6", "item-7", "item-9", "item-10"}, report.Values())
+	assert.EqualError(t, report.Err(), "item 3: failed 4\nitem 7: failed 8")
+
+	assert.Len(t, progress, 10, "progress is reported for every item")
+	assert.Equal(t, BatchProgress{Total: 10, Done: 10, Failed: 2}, progress[9])
+}
+
+func TestBatchFailFast(t *testing.T) {
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+	errFailed := errors.New("failed")
+
+	var mu sync.Mutex
+	var processed []int
+	report := ForEach(context.Background(), items, func(ctx context.Context, item int) error {
+		mu.Lock()
+		processed = append(processed, item)
+		mu.Unlock()
+		if item == 0 {
+			return errFailed
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}, BatchOptions{Concurrency: 2, FailFast: true})
+
+	assert.ErrorIs(t, report.Err(), errFailed)
+	assert.ErrorIs(t, report.Results[0].Err, errFailed)
+	assert.LessOrEqual(t, len(processed), 3, "remaining items are not processed")
+	assert.ErrorIs(t, report.Results[99].Err, ErrSkipped, "remaining items are skipped")
+	assert.Empty(t, report.Succeeded())
+}
+
+func TestBatchCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := ForEach(ctx, []string{"a", "b"}, func(ctx context.Context, item string) error {
+		t.Error("no item is processed")
+		return nil
+	}, BatchOptions{})
+
+	assert.ErrorIs(t, report.Results[1].Err, context.Canceled)
+	assert.ErrorIs(t, report.Err(), context.Canceled)
+}
+
+func TestBatchUsesClientRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{ReadRate: 1000, ReadBurst: 1})
+	transport := roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{ "response": { "server": { "serverid": "kvm12345" } } }`)),
+		}, nil
+	})
+	client, _ := NewClientWithOptions("project-id", "api-key", WithRateLimiter(limiter), WithHTTPClient(&http.Client{Transport: transport}))
+
+	report := Batch(context.Background(), []string{"kvm1", "kvm2", "kvm3"}, func(ctx context.Context, id string) (*ServerDetails, error) {
+		return client.Servers.Details(ctx, id)
+	}, BatchOptions{Concurrency: 3})
+
+	assert.NoError(t, report.Err())
+	assert.Len(t, report.Values(), 3)
+	assert.NotZero(t, limiter.Stats().Waits, "calls are rate limited")
+}
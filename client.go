@@ -1,14 +1,10 @@
 package glesys
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
-	"strings"
 )
 
 const version = "8.5.0"
@@ -24,11 +20,17 @@ type clientInterface interface {
 
 // Client is used to interact with the GleSYS API
 type Client struct {
-	apiKey     string
-	BaseURL    *url.URL
-	httpClient httpClientInterface
-	project    string
-	userAgent  string
+	apiKey      string
+	BaseURL     *url.URL
+	httpClient  httpClientInterface
+	logger      *slog.Logger
+	logOptions  LogOptions
+	metrics     MetricsRecorder
+	middleware  []Middleware
+	rateLimiter *RateLimiter
+	project     string
+	retryPolicy *RetryPolicy
+	userAgent   string
 
 	Databases       *DatabaseService
 	DNSDomains      *DNSDomainService
@@ -44,17 +46,71 @@ type Client struct {
 	NetworkCircuits *NetworkCircuitService
 }
 
+// Services are the services of a Client as interfaces. Code which accepts
+// Services instead of a Client can be tested with other implementations,
+// for example the fakes in the glesysmock package.
+type Services struct {
+	Databases       DatabaseAPI
+	DNSDomains      DNSDomainAPI
+	EmailDomains    EmailDomainAPI
+	IPs             IPAPI
+	LoadBalancers   LoadBalancerAPI
+	ObjectStorages  ObjectStorageAPI
+	PrivateNetworks PrivateNetworkAPI
+	Servers         ServerAPI
+	ServerDisks     ServerDisksAPI
+	Networks        NetworkAPI
+	NetworkAdapters NetworkAdapterAPI
+	NetworkCircuits NetworkCircuitAPI
+}
+
+// Services returns the services of the client as interfaces. The returned
+// Services can be modified without affecting the client.
+func (c *Client) Services() *Services {
+	return &Services{
+		Databases:       c.Databases,
+		DNSDomains:      c.DNSDomains,
+		EmailDomains:    c.EmailDomains,
+		IPs:             c.IPs,
+		LoadBalancers:   c.LoadBalancers,
+		ObjectStorages:  c.ObjectStorages,
+		PrivateNetworks: c.PrivateNetworks,
+		Servers:         c.Servers,
+		ServerDisks:     c.ServerDisks,
+		Networks:        c.Networks,
+		NetworkAdapters: c.NetworkAdapters,
+		NetworkCircuits: c.NetworkCircuits,
+	}
+}
+
 // NewClient creates a new Client for interacting with the GleSYS API. This is
 // the main entrypoint for API interactions.
 func NewClient(project, apiKey, userAgent string) *Client {
-	BaseURL, _ := url.Parse("https://api.glesys.com")
+	c, _ := NewClientWithOptions(project, apiKey, WithUserAgent(userAgent))
+	return c
+}
+
+// NewClientWithOptions creates a new Client configured by opts. The returned
+// Client is safe for concurrent use as long as it is not modified after
+// creation.
+func NewClientWithOptions(project, apiKey string, opts ...Option) (*Client, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
 
 	c := &Client{
-		apiKey:     apiKey,
-		BaseURL:    BaseURL,
-		httpClient: http.DefaultClient,
-		project:    project,
-		userAgent:  userAgent,
+		apiKey:      apiKey,
+		BaseURL:     o.baseURL,
+		httpClient:  o.buildHTTPClient(),
+		logger:      o.logger,
+		logOptions:  o.logOptions,
+		metrics:     o.metrics,
+		middleware:  o.middleware,
+		rateLimiter: o.rateLimiter,
+		project:     project,
+		retryPolicy: o.retryPolicy,
+		userAgent:   o.userAgent,
 	}
 
 	c.Databases = &DatabaseService{client: c}
@@ -65,15 +121,19 @@ func NewClient(project, apiKey, userAgent string) *Client {
 	c.ObjectStorages = &ObjectStorageService{client: c}
 	c.PrivateNetworks = &PrivateNetworkService{client: c}
 	c.Servers = &ServerService{client: c}
+	if o.paramsValidation > 0 {
+		c.Servers = &ServerService{client: c, validator: &allowedArgumentsCache{ttl: o.paramsValidation}}
+	}
 	c.ServerDisks = &ServerDisksService{client: c}
 	c.Networks = &NetworkService{client: c}
 	c.NetworkAdapters = &NetworkAdapterService{client: c}
 	c.NetworkCircuits = &NetworkCircuitService{client: c}
 
-	return c
+	return c, nil
 }
 
-// SetBaseURL can be used to set a custom BaseURL
+// SetBaseURL can be used to set a custom BaseURL. It is not safe to call
+// SetBaseURL while the Client is in use, see WithBaseURL.
 func (c *Client) SetBaseURL(bu string) error {
 	url, err := url.Parse(bu)
 	if err != nil {
@@ -83,95 +143,55 @@ func (c *Client) SetBaseURL(bu string) error {
 	return nil
 }
 
-func (c *Client) get(ctx context.Context, path string, v interface{}) error {
-	request, err := c.newRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return err
-	}
-	return c.do(request, v)
-}
-
-func (c *Client) post(ctx context.Context, path string, v interface{}, params interface{}) error {
-	request, err := c.newRequest(ctx, "POST", path, params)
-	if err != nil {
-		return err
-	}
-	return c.do(request, v)
+// RateLimiter returns the rate limiter of the client, or nil if calls are not
+// rate limited. See WithRateLimiter.
+func (c *Client) RateLimiter() *RateLimiter {
+	return c.rateLimiter
 }
 
-func (c *Client) newRequest(ctx context.Context, method, path string, params interface{}) (*http.Request, error) {
-	u, err := url.Parse(path)
-	if err != nil {
-		return nil, err
+// pipeline returns the request pipeline for the current configuration and
+// the project of ctx, see WithProject.
+func (c *Client) pipeline(ctx context.Context) *pipeline {
+	p := &pipeline{
+		baseURL:     c.BaseURL,
+		httpClient:  c.httpClient,
+		logger:      c.logger,
+		logOptions:  c.logOptions,
+		metrics:     c.metrics,
+		middleware:  c.middleware,
+		rateLimiter: c.rateLimiter,
+		password:    c.apiKey,
+		project:     c.project,
+		retryPolicy: c.retryPolicy,
+		userAgent:   c.userAgent,
+		username:    c.project,
 	}
-
-	if c.BaseURL != nil {
-		u = c.BaseURL.ResolveReference(u)
-	}
-
-	buffer := new(bytes.Buffer)
-
-	if params != nil {
-		err = json.NewEncoder(buffer).Encode(params)
-		if err != nil {
-			return nil, err
-		}
+	if credentials, ok := ctx.Value(projectContextKey{}).(projectCredentials); ok {
+		p.project = credentials.project
+		p.username = credentials.project
+		p.password = credentials.apiKey
 	}
-
-	request, err := http.NewRequestWithContext(ctx, method, u.String(), buffer)
-	if err != nil {
-		return nil, err
-	}
-
-	userAgent := strings.TrimSpace(fmt.Sprintf("%s glesys-go/%s", c.userAgent, version))
-
-	request.Header.Set("Content-Type", "application/json")
-	request.Header.Set("User-Agent", userAgent)
-	request.SetBasicAuth(c.project, c.apiKey)
-
-	return request, nil
+	return p
 }
 
-func (c *Client) do(request *http.Request, v interface{}) error {
-	response, err := c.httpClient.Do(request)
-	if err != nil {
-		return err
-	}
-
-	if response.StatusCode != http.StatusOK {
-		return handleResponseError(response)
-	}
-
-	return parseResponseBody(response, v)
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	return c.pipeline(ctx).call(ctx, "GET", path, v, nil)
 }
 
-func handleResponseError(response *http.Response) error {
-	data := struct {
-		Response struct {
-			Status struct {
-				Text string `json:"text"`
-			} `json:"status"`
-		} `json:"response"`
-	}{}
-
-	err := parseResponseBody(response, &data)
-	if err != nil {
-		return err
-	}
-
-	return fmt.Errorf("request failed with HTTP error: %v (%v)", response.StatusCode, strings.TrimSpace(data.Response.Status.Text))
+func (c *Client) post(ctx context.Context, path string, v interface{}, params interface{}) error {
+	return c.pipeline(ctx).call(ctx, "POST", path, v, params)
 }
 
-func parseResponseBody(response *http.Response, v interface{}) error {
-	if v == nil {
-		return nil
-	}
-
-	defer response.Body.Close()
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		return err
-	}
+func (c *Client) newRequest(ctx context.Context, method, path string, params interface{}) (*http.Request, error) {
+	return c.pipeline(ctx).newRequest(ctx, method, path, params)
+}
 
-	return json.Unmarshal(body, v)
+func (c *Client) do(request *http.Request, v interface{}) error {
+	p := c.pipeline(request.Context())
+	return p.do(&Request{
+		Method:      request.Method,
+		Path:        apiPath(c.BaseURL, request.URL),
+		Project:     p.project,
+		HTTPRequest: request,
+	}, v)
 }
@@ -129,3 +129,14 @@ func TestPost(t *testing.T) {
 	assert.Equal(t, "POST", mockClient.lastRequest.Method, "method used is correct")
 	assert.Equal(t, "bar", params.Foo, "params are correct")
 }
+
+func TestClientServices(t *testing.T) {
+	client := NewClient("CL12345", "api-key", "test-application/0.0.1")
+
+	services := client.Services()
+	assert.Same(t, client.Servers, services.Servers, "services of the client are returned")
+	assert.Same(t, client.NetworkCircuits, services.NetworkCircuits)
+
+	services.Servers = nil
+	assert.NotNil(t, client.Servers, "client is not modified")
+}
@@ -0,0 +1,98 @@
+package glesys
+
+import "context"
+
+// CloneServerParams is used when cloning a server. Resources which are not
+// set are the same as those of the cloned server.
+type CloneServerParams struct {
+	Bandwidth   int    `json:"bandwidth,omitempty"`
+	CPU         int    `json:"cpucores,omitempty"`
+	DataCenter  string `json:"datacenter,omitempty"`
+	Description string `json:"description,omitempty"`
+	Hostname    string `json:"hostname"`
+	Memory      int    `json:"memorysize,omitempty"`
+	Storage     int    `json:"disksize,omitempty"`
+}
+
+// CloneParams returns the parameters to create a server like s, with the
+// overrides of params, and the parameters of its additional disks. It is a
+// fallback for cases where ServerService.Clone is not available, e.g. across
+// data centers. Credentials, IP addresses and cloud-config are not copied,
+// new IP addresses are assigned and the users or password must be set before
+// the server is created. The ServerID of the disks must be set to that of the
+// new server before they are created using ServerDisksService.Create.
+func (s *ServerDetails) CloneParams(params CloneServerParams) (CreateServerParams, []CreateServerDiskParams) {
+	create := createServerParamsFromDetails(s)
+	create.Hostname = params.Hostname
+	create.Description = params.Description
+	create.IPv4 = "any"
+	create.IPv6 = "any"
+	if params.DataCenter != "" {
+		create.DataCenter = params.DataCenter
+	}
+	if params.CPU != 0 {
+		create.CPU = params.CPU
+	}
+	if params.Memory != 0 {
+		create.Memory = params.Memory
+	}
+	if params.Storage != 0 {
+		create.Storage = params.Storage
+	}
+	if params.Bandwidth != 0 {
+		create.Bandwidth = params.Bandwidth
+	}
+
+	var disks []CreateServerDiskParams
+	for _, disk := range s.AdditionalDisks {
+		disks = append(disks, CreateServerDiskParams{
+			Name:      disk.Name,
+			SizeInGIB: disk.SizeInGIB,
+			Type:      disk.Type,
+		})
+	}
+	return create, disks
+}
+
+// RecreateServer creates a server like the server with serverID, see
+// ServerDetails.CloneParams, and its additional disks once the new server
+// is unlocked. The create function may modify the parameters before the
+// server is created, e.g. to set the users, and is not called if nil. Once
+// the new server is created it is returned also when an error is returned,
+// e.g. if waiting for it or creating a disk fails, so that it can be cleaned
+// up.
+func RecreateServer(ctx context.Context, servers ServerAPI, disks ServerDisksAPI, serverID string, params CloneServerParams, create func(*CreateServerParams), opts ...WaitOption) (*ServerDetails, error) {
+	server, err := servers.Details(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	createParams, diskParams := server.CloneParams(params)
+	if create != nil {
+		create(&createParams)
+	}
+	clone, err := servers.Create(ctx, createParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(diskParams) == 0 {
+		return clone, nil
+	}
+
+	unlocked, err := WaitUntilServerUnlocked(ctx, servers, clone.ID, opts...)
+	if unlocked != nil {
+		clone = unlocked
+	}
+	if err != nil {
+		return clone, err
+	}
+	for _, disk := range diskParams {
+		disk.ServerID = clone.ID
+		created, err := disks.Create(ctx, disk)
+		if err != nil {
+			return clone, err
+		}
+		clone.AdditionalDisks = append(clone.AdditionalDisks, *created)
+	}
+	return clone, nil
+}
@@ -0,0 +1,92 @@
+package glesys
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServersClone(t *testing.T) {
+	c := &mockClient{body: `{"response": {"server": {"serverid": "kvm234567", "hostname": "web-04"}}}`}
+	s := ServerService{client: c}
+
+	server, err := s.Clone(context.Background(), "kvm123456", CloneServerParams{Hostname: "web-04", DataCenter: "Stockholm"})
+	assert.NoError(t, err)
+	assert.Equal(t, "POST", c.lastMethod, "method used is correct")
+	assert.Equal(t, "server/clone", c.lastPath, "path used is correct")
+	assert.Equal(t, "kvm234567", server.ID)
+}
+
+func TestServerDetailsCloneParams(t *testing.T) {
+	server := ServerDetails{
+		AdditionalDisks: []ServerDiskDetails{{ID: "disk-1", Name: "data", SizeInGIB: 100, Type: "gold"}},
+		Backup:          ServerBackupDetails{Enabled: "yes", Schedules: []ServerBackupSchedule{{Frequency: "daily", Numberofimagestokeep: 7}}},
+		Bandwidth:       100,
+		CPU:             2,
+		DataCenter:      "Falkenberg",
+		Description:     "web",
+		Hostname:        "web-03",
+		InitialTemplate: ServerTemplateDetails{Name: "Debian 12 (Bookworm)"},
+		Memory:          2048,
+		Platform:        "KVM",
+		Storage:         50,
+	}
+
+	params, disks := server.CloneParams(CloneServerParams{Hostname: "web-04", DataCenter: "Stockholm", Memory: 4096})
+	assert.Equal(t, CreateServerParams{
+		Backup:     server.Backup.Schedules,
+		Bandwidth:  100,
+		CPU:        2,
+		DataCenter: "Stockholm",
+		Hostname:   "web-04",
+		IPv4:       "any",
+		IPv6:       "any",
+		Memory:     4096,
+		Platform:   "KVM",
+		Storage:    50,
+		Template:   "Debian 12 (Bookworm)",
+	}, params)
+	assert.Equal(t, []CreateServerDiskParams{{Name: "data", SizeInGIB: 100, Type: "gold"}}, disks)
+}
+
+func TestRecreateServer(t *testing.T) {
+	source := `{"response": {"server": {"serverid": "kvm123456", "platform": "KVM", "cpucores": 2,
+		"initialtemplate": {"name": "Debian 12 (Bookworm)"}, "additionaldisks": [{"name": "data", "sizeingib": 100}]}}}`
+	created := `{"response": {"server": {"serverid": "kvm234567", "islocked": true, "state": "locked"}}}`
+	unlocked := `{"response": {"server": {"serverid": "kvm234567", "state": "running", "isrunning": true}}}`
+	servers := &ServerService{client: &sequenceClient{bodies: []string{source, created, unlocked}}}
+	disksClient := &mockClient{body: `{"response": {"disk": {"id": "disk-2", "name": "data", "sizeingib": 100}}}`}
+	disks := &ServerDisksService{client: disksClient}
+
+	var createParams CreateServerParams
+	server, err := RecreateServer(context.Background(), servers, disks, "kvm123456", CloneServerParams{Hostname: "web-04"},
+		func(p *CreateServerParams) {
+			p.Password = "secret"
+			createParams = *p
+		},
+		WithPollInterval(time.Millisecond, time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", createParams.Password, "parameters are modified before the server is created")
+	assert.Equal(t, "kvm234567", server.ID)
+	assert.Equal(t, "serverdisk/create", disksClient.lastPath, "additional disk is created")
+	assert.Equal(t, []ServerDiskDetails{{ID: "disk-2", Name: "data", SizeInGIB: 100}}, server.AdditionalDisks)
+}
+
+func TestRecreateServerReturnsCreatedServerOnWaitError(t *testing.T) {
+	source := `{"response": {"server": {"serverid": "kvm123456", "platform": "KVM",
+		"initialtemplate": {"name": "Debian 12 (Bookworm)"}, "additionaldisks": [{"name": "data", "sizeingib": 100}]}}}`
+	created := `{"response": {"server": {"serverid": "kvm234567", "islocked": true, "state": "locked"}}}`
+	servers := &ServerService{client: &sequenceClient{bodies: []string{source, created, "error"}}}
+	disksClient := &mockClient{}
+	disks := &ServerDisksService{client: disksClient}
+
+	server, err := RecreateServer(context.Background(), servers, disks, "kvm123456", CloneServerParams{Hostname: "web-04"}, nil,
+		WithPollInterval(time.Millisecond, time.Millisecond))
+	assert.ErrorIs(t, err, ErrNotFound)
+	if assert.NotNil(t, server, "created server is returned") {
+		assert.Equal(t, "kvm234567", server.ID)
+	}
+	assert.Empty(t, disksClient.lastPath, "no disk is created")
+}
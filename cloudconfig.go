@@ -0,0 +1,167 @@
+package glesys
+
+import "go.yaml.in/yaml/v3"
+
+// CloudConfig is a typed cloud-init cloud-config, which is rendered as YAML
+// for CreateServerParams.CloudConfig. The methods modify the config and
+// return it to allow chaining:
+//
+//	config := glesys.NewCloudConfig().
+//		AddPackages("nginx").
+//		AddFile(glesys.CloudConfigFile{Path: "/var/www/html/index.html", Content: "Hello {{params.name}}"}).
+//		RunCommand("systemctl", "enable", "--now", "nginx")
+//
+//	params := glesys.CreateServerParams{Hostname: "web-01"}.WithDefaults().WithCloudConfig(config)
+//
+// Strings may contain mustache placeholders which are rendered by the API
+// using CreateServerParams.CloudConfigParams and Users.
+type CloudConfig struct {
+	Timezone          string               `yaml:"timezone,omitempty"`
+	Users             []CloudConfigUser    `yaml:"users,omitempty"`
+	SSHAuthorizedKeys []string             `yaml:"ssh_authorized_keys,omitempty"`
+	PackageUpdate     bool                 `yaml:"package_update,omitempty"`
+	PackageUpgrade    bool                 `yaml:"package_upgrade,omitempty"`
+	Packages          []string             `yaml:"packages,omitempty"`
+	WriteFiles        []CloudConfigFile    `yaml:"write_files,omitempty"`
+	Mounts            [][]string           `yaml:"mounts,omitempty"`
+	BootCmd           []CloudConfigCommand `yaml:"bootcmd,omitempty"`
+	RunCmd            []CloudConfigCommand `yaml:"runcmd,omitempty"`
+}
+
+// CloudConfigUser is a user created by cloud-init.
+type CloudConfigUser struct {
+	Name              string   `yaml:"name"`
+	Groups            []string `yaml:"groups,omitempty"`
+	Shell             string   `yaml:"shell,omitempty"`
+	Sudo              string   `yaml:"sudo,omitempty"`
+	SSHAuthorizedKeys []string `yaml:"ssh_authorized_keys,omitempty"`
+	// HashedPassword is the password hash, e.g. created by mkpasswd.
+	HashedPassword string `yaml:"hashed_passwd,omitempty"`
+	LockPassword   *bool  `yaml:"lock_passwd,omitempty"`
+}
+
+// CloudConfigFile is a file written by cloud-init.
+type CloudConfigFile struct {
+	Path    string `yaml:"path"`
+	Content string `yaml:"content"`
+	// Owner is the owner of the file, e.g. "root:root".
+	Owner string `yaml:"owner,omitempty"`
+	// Permissions are the permissions of the file in octal, e.g. "0644".
+	Permissions string `yaml:"permissions,omitempty"`
+	// Encoding of the content, e.g. "b64". Defaults to plain text.
+	Encoding string `yaml:"encoding,omitempty"`
+	Append   bool   `yaml:"append,omitempty"`
+	// Defer writes the file after packages have been installed and users
+	// created.
+	Defer bool `yaml:"defer,omitempty"`
+}
+
+// CloudConfigCommand is a command run by cloud-init. Commands with a Shell
+// command are run by the shell, otherwise Args are executed directly.
+type CloudConfigCommand struct {
+	Shell string
+	Args  []string
+}
+
+// MarshalYAML renders the command as a string or a list.
+func (c CloudConfigCommand) MarshalYAML() (interface{}, error) {
+	if c.Shell != "" {
+		return c.Shell, nil
+	}
+	return c.Args, nil
+}
+
+// NewCloudConfig creates an empty CloudConfig.
+func NewCloudConfig() *CloudConfig {
+	return &CloudConfig{}
+}
+
+// SetTimezone sets the timezone of the server, e.g. "Europe/Stockholm".
+func (c *CloudConfig) SetTimezone(timezone string) *CloudConfig {
+	c.Timezone = timezone
+	return c
+}
+
+// AddUser adds a user with the username and public keys of user. The
+// password is not included since cloud-init requires a hash, pass the user to
+// CreateServerParams.WithUser to have the password set by the API.
+func (c *CloudConfig) AddUser(user User) *CloudConfig {
+	c.Users = append(c.Users, CloudConfigUser{
+		Name:              user.Username,
+		SSHAuthorizedKeys: user.PublicKeys,
+	})
+	return c
+}
+
+// AddCloudConfigUser adds a user with all cloud-init settings available.
+func (c *CloudConfig) AddCloudConfigUser(user CloudConfigUser) *CloudConfig {
+	c.Users = append(c.Users, user)
+	return c
+}
+
+// AddSSHKeys authorizes the public keys for the default user of the image.
+func (c *CloudConfig) AddSSHKeys(keys ...string) *CloudConfig {
+	c.SSHAuthorizedKeys = append(c.SSHAuthorizedKeys, keys...)
+	return c
+}
+
+// AddPackages installs the packages. The package lists are updated first.
+func (c *CloudConfig) AddPackages(packages ...string) *CloudConfig {
+	c.PackageUpdate = true
+	c.Packages = append(c.Packages, packages...)
+	return c
+}
+
+// AddFile writes a file.
+func (c *CloudConfig) AddFile(file CloudConfigFile) *CloudConfig {
+	c.WriteFiles = append(c.WriteFiles, file)
+	return c
+}
+
+// AddMount adds an entry to /etc/fstab. The fields are those of fstab, i.e.
+// device, mount point, file system type, options, dump and pass. Omitted
+// fields use the defaults of cloud-init.
+func (c *CloudConfig) AddMount(fields ...string) *CloudConfig {
+	c.Mounts = append(c.Mounts, fields)
+	return c
+}
+
+// BootCommand runs a command early on every boot.
+func (c *CloudConfig) BootCommand(args ...string) *CloudConfig {
+	c.BootCmd = append(c.BootCmd, CloudConfigCommand{Args: args})
+	return c
+}
+
+// BootShell runs a shell command early on every boot.
+func (c *CloudConfig) BootShell(command string) *CloudConfig {
+	c.BootCmd = append(c.BootCmd, CloudConfigCommand{Shell: command})
+	return c
+}
+
+// RunCommand runs a command on the first boot.
+func (c *CloudConfig) RunCommand(args ...string) *CloudConfig {
+	c.RunCmd = append(c.RunCmd, CloudConfigCommand{Args: args})
+	return c
+}
+
+// RunShell runs a shell command on the first boot.
+func (c *CloudConfig) RunShell(command string) *CloudConfig {
+	c.RunCmd = append(c.RunCmd, CloudConfigCommand{Shell: command})
+	return c
+}
+
+// String renders the config as a #cloud-config YAML document.
+func (c *CloudConfig) String() string {
+	// Marshal only fails for types which can not be represented in YAML,
+	// which CloudConfig does not contain.
+	out, _ := yaml.Marshal(c)
+	return "#cloud-config\n" + string(out)
+}
+
+// WithCloudConfig sets the CloudConfig parameter of CreateServerParams to
+// the rendered config. Existing parameters, e.g. Users set with WithUser, are
+// not modified.
+func (p CreateServerParams) WithCloudConfig(config *CloudConfig) CreateServerParams {
+	p.CloudConfig = config.String()
+	return p
+}
@@ -0,0 +1,86 @@
+package glesys
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.yaml.in/yaml/v3"
+)
+
+func TestCloudConfigString(t *testing.T) {
+	lock := true
+	config := NewCloudConfig().
+		SetTimezone("Europe/Stockholm").
+		AddUser(User{Username: "alice", PublicKeys: []string{"ssh-ed25519 AAAA alice"}, Password: "secret"}).
+		AddCloudConfigUser(CloudConfigUser{Name: "deploy", Groups: []string{"sudo", "docker"}, Shell: "/bin/bash", Sudo: "ALL=(ALL) NOPASSWD:ALL", LockPassword: &lock}).
+		AddSSHKeys("ssh-ed25519 BBBB admin").
+		AddPackages("nginx", "curl").
+		AddFile(CloudConfigFile{Path: "/var/www/html/index.html", Content: "Hello {{params.name}}\n", Permissions: "0644"}).
+		AddMount("/dev/vdb", "/data", "ext4", "defaults").
+		BootShell("echo booting > /dev/console").
+		RunCommand("systemctl", "enable", "--now", "nginx").
+		RunShell("curl -fsS http://localhost/ | grep Hello")
+
+	expected := `#cloud-config
+timezone: Europe/Stockholm
+users:
+    - name: alice
+      ssh_authorized_keys:
+        - ssh-ed25519 AAAA alice
+    - name: deploy
+      groups:
+        - sudo
+        - docker
+      shell: /bin/bash
+      sudo: ALL=(ALL) NOPASSWD:ALL
+      lock_passwd: true
+ssh_authorized_keys:
+    - ssh-ed25519 BBBB admin
+package_update: true
+packages:
+    - nginx
+    - curl
+write_files:
+    - path: /var/www/html/index.html
+      content: |
+        Hello {{params.name}}
+      permissions: "0644"
+mounts:
+    - - /dev/vdb
+      - /data
+      - ext4
+      - defaults
+bootcmd:
+    - echo booting > /dev/console
+runcmd:
+    - - systemctl
+      - enable
+      - --now
+      - nginx
+    - curl -fsS http://localhost/ | grep Hello
+`
+	assert.Equal(t, expected, config.String())
+	assert.NotContains(t, config.String(), "secret", "passwords are not included")
+}
+
+func TestCloudConfigIsValidYAML(t *testing.T) {
+	config := NewCloudConfig().AddFile(CloudConfigFile{Path: "/etc/motd", Content: "{{#users}}{{username}}{{/users}}"}).RunShell("echo '{{params.greeting}}: done'")
+
+	var parsed map[string]interface{}
+	assert.NoError(t, yaml.Unmarshal([]byte(config.String()), &parsed))
+	assert.Equal(t, "{{#users}}{{username}}{{/users}}", parsed["write_files"].([]interface{})[0].(map[string]interface{})["content"], "mustache placeholders are kept")
+	assert.Equal(t, []interface{}{"echo '{{params.greeting}}: done'"}, parsed["runcmd"])
+	assert.Equal(t, "#cloud-config\n{}\n", NewCloudConfig().String(), "empty config is valid")
+}
+
+func TestCreateServerParamsWithCloudConfig(t *testing.T) {
+	config := NewCloudConfig().AddPackages("nginx")
+
+	params := CreateServerParams{Hostname: "web-01"}.
+		WithUser("alice", []string{"ssh-ed25519 AAAA alice"}, "secret").
+		WithCloudConfig(config)
+
+	assert.Equal(t, config.String(), params.CloudConfig, "rendered config is set")
+	assert.Equal(t, "web-01", params.Hostname, "existing parameters are kept")
+	assert.Len(t, params.Users, 1, "users are kept")
+}
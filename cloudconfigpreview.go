@@ -0,0 +1,213 @@
+package glesys
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/glesys/glesys-go/v8/internal/mustache"
+	"github.com/glesys/glesys-go/v8/internal/shacrypt"
+)
+
+// RenderCloudConfig renders a cloud-config template locally, like
+// ServerService.PreviewCloudConfig does using the API. The template is
+// rendered with the same context, `params` for the CloudConfigParams and
+// `users` for the Users with the keys `username`, `password` and `sshKeys`,
+// which makes it possible to test templates offline:
+//
+//	{{#users}}
+//	  - name: {{username}}
+//	{{/users}}
+//	timezone: {{params.timezone}}
+//
+// The `{{>users}}` partial renders the users like the API. Passwords are
+// hashed using a random salt, so the hashes differ from those of the API.
+// Other partials return an error.
+//
+// Variables are HTML escaped according to the mustache specification, use
+// triple mustaches to render values containing &, <, > or " as is. Lambdas
+// are not supported.
+func RenderCloudConfig(params PreviewCloudConfigParams) (*CloudConfigPreview, error) {
+	previewContext := PreviewContext{Params: params.CloudConfigParams, Users: params.Users}
+	if previewContext.Users == nil {
+		previewContext.Users = []User{}
+	}
+
+	users := make([]map[string]any, len(previewContext.Users))
+	for i, user := range previewContext.Users {
+		keys := user.PublicKeys
+		if keys == nil {
+			keys = []string{}
+		}
+		users[i] = map[string]any{"username": user.Username, "password": user.Password, "sshKeys": keys}
+	}
+	data := map[string]any{"params": previewContext.Params, "users": users}
+	partials := map[string]string{"users": renderCloudConfigUsers(previewContext.Users)}
+
+	preview, err := mustache.Render(params.CloudConfig, data, partials)
+	if err != nil {
+		return nil, fmt.Errorf("glesys: rendering cloud-config: %w", err)
+	}
+	return &CloudConfigPreview{Preview: preview, Context: previewContext}, nil
+}
+
+// cloudConfigSalt returns the salt used to hash the passwords of users.
+var cloudConfigSalt = func() string {
+	salt := make([]byte, 8)
+	rand.Read(salt)
+	return hex.EncodeToString(salt)
+}
+
+// plainYAML matches strings which are rendered without quotes.
+var plainYAML = regexp.MustCompile(`^[A-Za-z0-9_$./][A-Za-z0-9_$./@-]*$`)
+
+// renderCloudConfigUsers renders the users partial the way the API does.
+func renderCloudConfigUsers(users []User) string {
+	if len(users) == 0 {
+		return "users: []\n"
+	}
+
+	var b strings.Builder
+	b.WriteString("users:\n")
+	for _, user := range users {
+		b.WriteString("    -\n")
+		fmt.Fprintf(&b, "        name: %s\n", yamlString(user.Username))
+		b.WriteString("        shell: /bin/bash\n")
+		b.WriteString("        lock_passwd: false\n")
+		b.WriteString("        sudo: 'ALL=(ALL) PASSWD:ALL'\n")
+		if user.Password != "" {
+			fmt.Fprintf(&b, "        passwd: %s\n", shacrypt.SHA512(user.Password, cloudConfigSalt()))
+		}
+		if len(user.PublicKeys) > 0 {
+			keys := make([]string, len(user.PublicKeys))
+			for i, key := range user.PublicKeys {
+				keys[i] = quoteYAML(key)
+			}
+			fmt.Fprintf(&b, "        ssh_authorized_keys: [%s]\n", strings.Join(keys, ", "))
+		}
+	}
+	b.WriteString("ssh_pwauth: false\n")
+	b.WriteString("chpasswd:\n")
+	b.WriteString("    expire: false\n")
+	return b.String()
+}
+
+func yamlString(s string) string {
+	if plainYAML.MatchString(s) {
+		return s
+	}
+	return quoteYAML(s)
+}
+
+func quoteYAML(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// CloudConfigComparison is the result of CompareCloudConfig.
+type CloudConfigComparison struct {
+	// Local is the cloud-config rendered by RenderCloudConfig.
+	Local string
+	// Remote is the cloud-config rendered by the API.
+	Remote string
+	// Differences are the lines which differ, in order.
+	Differences []CloudConfigDifference
+}
+
+// CloudConfigDifference is a line which only exists in one of the renderings.
+type CloudConfigDifference struct {
+	// LocalLine is the line number in the local rendering, or 0 if the line
+	// only exists in the remote rendering.
+	LocalLine int
+	// RemoteLine is the line number in the remote rendering, or 0 if the line
+	// only exists in the local rendering.
+	RemoteLine int
+	// Text is the line.
+	Text string
+}
+
+// Equal reports whether the renderings are equal.
+func (c *CloudConfigComparison) Equal() bool {
+	return len(c.Differences) == 0
+}
+
+// Diff returns the differences with local lines prefixed by - and remote
+// lines prefixed by +.
+func (c *CloudConfigComparison) Diff() string {
+	var b strings.Builder
+	for _, d := range c.Differences {
+		if d.RemoteLine == 0 {
+			fmt.Fprintf(&b, "-%d: %s\n", d.LocalLine, d.Text)
+		} else {
+			fmt.Fprintf(&b, "+%d: %s\n", d.RemoteLine, d.Text)
+		}
+	}
+	return b.String()
+}
+
+// CompareCloudConfig renders a cloud-config template both locally and using
+// the API, and reports the lines which differ. It is useful to verify that
+// templates tested offline render the same way when creating servers. The
+// passwd lines of the users partial always differ, since the passwords are
+// hashed using random salts.
+func CompareCloudConfig(ctx context.Context, servers ServerAPI, params PreviewCloudConfigParams) (*CloudConfigComparison, error) {
+	local, err := RenderCloudConfig(params)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := servers.PreviewCloudConfig(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CloudConfigComparison{
+		Local:       local.Preview,
+		Remote:      remote.Preview,
+		Differences: diffLines(splitLines(local.Preview), splitLines(remote.Preview)),
+	}, nil
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimRight(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// diffLines returns the lines which are not part of the longest common
+// subsequence of a and b.
+func diffLines(a, b []string) []CloudConfigDifference {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var differences []CloudConfigDifference
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			differences = append(differences, CloudConfigDifference{LocalLine: i + 1, Text: a[i]})
+			i++
+		default:
+			differences = append(differences, CloudConfigDifference{RemoteLine: j + 1, Text: b[j]})
+			j++
+		}
+	}
+	return differences
+}
@@ -0,0 +1,95 @@
+package glesys
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testCloudConfigTemplate = `#cloud-config
+users:
+{{#users}}
+  - name: {{username}}
+    ssh_authorized_keys:
+{{#sshKeys}}
+      - {{{.}}}
+{{/sshKeys}}
+{{/users}}
+timezone: {{params.timezone}}
+{{^params.packages}}
+packages: []
+{{/params.packages}}
+`
+
+func TestRenderCloudConfig(t *testing.T) {
+	params := PreviewCloudConfigParams{
+		CloudConfig:       testCloudConfigTemplate,
+		CloudConfigParams: map[string]any{"timezone": "Europe/Stockholm"},
+		Users:             []User{{Username: "alice", PublicKeys: []string{"ssh-ed25519 AAAA alice@example.com"}}},
+	}
+
+	preview, err := RenderCloudConfig(params)
+	assert.NoError(t, err)
+	assert.Equal(t, `#cloud-config
+users:
+  - name: alice
+    ssh_authorized_keys:
+      - ssh-ed25519 AAAA alice@example.com
+timezone: Europe/Stockholm
+packages: []
+`, preview.Preview)
+	assert.Equal(t, PreviewContext{Params: params.CloudConfigParams, Users: params.Users}, preview.Context, "context is returned")
+
+	_, err = RenderCloudConfig(PreviewCloudConfigParams{CloudConfig: "{{#users}}"})
+	assert.Error(t, err, "invalid template returns an error")
+}
+
+func TestRenderCloudConfigUsersPartial(t *testing.T) {
+	salt := cloudConfigSalt
+	cloudConfigSalt = func() string { return "ecb46c3c2a73263f" }
+	defer func() { cloudConfigSalt = salt }()
+
+	// The template and preview of TestServersPreviewCloudConfig.
+	params := PreviewCloudConfigParams{
+		CloudConfig: "## template: glesys\n#cloud-config\n{{>users}}\n",
+		Users:       []User{{Username: "bob", Password: "hunter333", PublicKeys: []string{"ssh-ed25519 AAAAKEY bob@bob-machine"}}},
+	}
+	preview, err := RenderCloudConfig(params)
+	assert.NoError(t, err)
+	assert.Equal(t, "## template: glesys\n#cloud-config\nusers:\n    -\n        name: bob\n        shell: /bin/bash\n        lock_passwd: false\n        sudo: 'ALL=(ALL) PASSWD:ALL'\n        passwd: $6$ecb46c3c2a73263f$wYkIrbHQzZ0zZvsb7PxdhIbskjOA4Ti5NnDe7EBBP.1SDAfborckfDcuYsqDmdgbGMFJgBzQMjXgJ4qHbLV5s.\n        ssh_authorized_keys: ['ssh-ed25519 AAAAKEY bob@bob-machine']\nssh_pwauth: false\nchpasswd:\n    expire: false\n", preview.Preview)
+
+	preview, err = RenderCloudConfig(PreviewCloudConfigParams{CloudConfig: "#cloud-config\n{{>users}}"})
+	assert.NoError(t, err)
+	assert.Equal(t, "#cloud-config\nusers: []\n", preview.Preview)
+
+	_, err = RenderCloudConfig(PreviewCloudConfigParams{CloudConfig: "#cloud-config\n{{>packages}}"})
+	assert.Error(t, err, "unknown partial returns an error")
+}
+
+func TestCompareCloudConfig(t *testing.T) {
+	params := PreviewCloudConfigParams{
+		CloudConfig:       testCloudConfigTemplate,
+		CloudConfigParams: map[string]any{"timezone": "Europe/Stockholm"},
+	}
+	local, _ := RenderCloudConfig(params)
+
+	remote, _ := json.Marshal(map[string]any{"response": map[string]any{"cloudconfig": map[string]any{"preview": local.Preview}}})
+	c := &mockClient{body: string(remote)}
+	comparison, err := CompareCloudConfig(context.Background(), &ServerService{client: c}, params)
+	assert.NoError(t, err)
+	assert.Equal(t, "server/previewcloudconfig", c.lastPath)
+	assert.True(t, comparison.Equal(), "equal renderings have no differences")
+
+	remote, _ = json.Marshal(map[string]any{"response": map[string]any{"cloudconfig": map[string]any{"preview": "#cloud-config\nusers:\ntimezone: UTC\npackages: []\n"}}})
+	c.body = string(remote)
+	comparison, err = CompareCloudConfig(context.Background(), &ServerService{client: c}, params)
+	assert.NoError(t, err)
+	assert.False(t, comparison.Equal())
+	assert.Equal(t, []CloudConfigDifference{
+		{LocalLine: 3, Text: "timezone: Europe/Stockholm"},
+		{RemoteLine: 3, Text: "timezone: UTC"},
+	}, comparison.Differences)
+	assert.Equal(t, "-3: timezone: Europe/Stockholm\n+3: timezone: UTC\n", comparison.Diff())
+}
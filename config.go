@@ -0,0 +1,220 @@
+package glesys
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Environment variables read by LoadConfig and NewClientFromEnvironment.
+const (
+	EnvProject    = "GLESYS_PROJECT"
+	EnvAPIKey     = "GLESYS_API_KEY"
+	EnvAPIURL     = "GLESYS_API_URL"
+	EnvUserAgent  = "GLESYS_USER_AGENT"
+	EnvProfile    = "GLESYS_PROFILE"
+	EnvConfigFile = "GLESYS_CONFIG_FILE"
+)
+
+// DefaultProfile is the profile used when no profile is selected and the
+// config file does not set default_profile.
+const DefaultProfile = "default"
+
+// ErrMissingConfig is matched by errors returned when a required setting is
+// not found in any source.
+var ErrMissingConfig = errors.New("glesys: missing configuration")
+
+// Config holds the settings needed to create a Client.
+type Config struct {
+	Project   string `yaml:"project"`
+	APIKey    string `yaml:"api_key"`
+	APIURL    string `yaml:"api_url"`
+	UserAgent string `yaml:"user_agent"`
+
+	// Profile is the name of the profile the config was loaded from, if any.
+	Profile string `yaml:"-"`
+	// Path is the path of the config file, if it was read.
+	Path string `yaml:"-"`
+}
+
+// configFile is the format of the config file, e.g.
+//
+//	default_profile: production
+//	profiles:
+//	  production:
+//	    project: CL12345
+//	    api_key: your-api-key
+//	  staging:
+//	    project: CL67890
+//	    api_key: your-other-api-key
+//	    api_url: https://dev-api.glesys.test
+type configFile struct {
+	DefaultProfile string             `yaml:"default_profile"`
+	Profiles       map[string]*Config `yaml:"profiles"`
+}
+
+// ConfigLoader loads a Config from the environment and the config file.
+//
+// Each setting is resolved on its own, in order of precedence:
+//
+//  1. The environment variables GLESYS_PROJECT, GLESYS_API_KEY,
+//     GLESYS_API_URL and GLESYS_USER_AGENT.
+//  2. The selected profile of the config file.
+//
+// The profile is selected by Profile, GLESYS_PROFILE, default_profile of the
+// config file or DefaultProfile, in that order. The config file is read from
+// Path, GLESYS_CONFIG_FILE or ~/.config/glesys/config.yaml, in that order.
+//
+// A missing config file is not an error unless a profile or path is given
+// explicitly.
+type ConfigLoader struct {
+	// Profile selects the profile of the config file.
+	Profile string
+	// Path is the path of the config file.
+	Path string
+}
+
+// LoadConfig loads a Config using the default ConfigLoader.
+func LoadConfig() (*Config, error) {
+	return ConfigLoader{}.Load()
+}
+
+// NewClientFromEnvironment creates a new Client configured by LoadConfig. The
+// options in opts take precedence over the loaded configuration.
+func NewClientFromEnvironment(opts ...Option) (*Client, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return config.NewClient(opts...)
+}
+
+// Load resolves the configuration. An error wrapping ErrMissingConfig is
+// returned if the project or API key is not found in any source.
+func (l ConfigLoader) Load() (*Config, error) {
+	path, explicitPath := l.path()
+
+	profile, explicitProfile := l.Profile, l.Profile != ""
+	if !explicitProfile {
+		profile, explicitProfile = os.Getenv(EnvProfile), os.Getenv(EnvProfile) != ""
+	}
+
+	config := &Config{}
+	file, err := readConfigFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist) && !explicitPath && !explicitProfile:
+		path = ""
+	case path == "":
+		return nil, fmt.Errorf("glesys: no config file found for profile %q, set %s or $HOME", profile, EnvConfigFile)
+	case err != nil:
+		return nil, fmt.Errorf("glesys: reading config file: %w", err)
+	default:
+		if profile == "" {
+			profile = file.DefaultProfile
+		}
+		if profile == "" {
+			profile = DefaultProfile
+		}
+		p, ok := file.Profiles[profile]
+		if !ok && (explicitProfile || file.DefaultProfile != "") {
+			return nil, fmt.Errorf("glesys: profile %q not found in %s", profile, path)
+		}
+		if ok && p != nil {
+			*config = *p
+			config.Profile = profile
+		}
+		config.Path = path
+	}
+
+	setFromEnv(&config.Project, EnvProject)
+	setFromEnv(&config.APIKey, EnvAPIKey)
+	setFromEnv(&config.APIURL, EnvAPIURL)
+	setFromEnv(&config.UserAgent, EnvUserAgent)
+
+	var missing []string
+	if config.Project == "" {
+		missing = append(missing, missingSource("project", EnvProject, "project", profile, path))
+	}
+	if config.APIKey == "" {
+		missing = append(missing, missingSource("API key", EnvAPIKey, "api_key", profile, path))
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissingConfig, strings.Join(missing, "; "))
+	}
+	return config, nil
+}
+
+// Options returns the options for the optional settings of the config.
+func (c *Config) Options() []Option {
+	var opts []Option
+	if c.APIURL != "" {
+		opts = append(opts, WithBaseURL(c.APIURL))
+	}
+	if c.UserAgent != "" {
+		opts = append(opts, WithUserAgent(c.UserAgent))
+	}
+	return opts
+}
+
+// NewClient creates a new Client using the config. The options in opts take
+// precedence over the config.
+func (c *Config) NewClient(opts ...Option) (*Client, error) {
+	return NewClientWithOptions(c.Project, c.APIKey, append(c.Options(), opts...)...)
+}
+
+// path returns the path of the config file and whether it was given
+// explicitly. The path is empty if it is not given and there is no home
+// directory, e.g. in containers, which is handled like a missing file.
+func (l ConfigLoader) path() (string, bool) {
+	if l.Path != "" {
+		return l.Path, true
+	}
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path, true
+	}
+
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "glesys", "config.yaml"), false
+}
+
+func readConfigFile(path string) (*configFile, error) {
+	if path == "" {
+		return nil, fs.ErrNotExist
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &configFile{}
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return file, nil
+}
+
+func setFromEnv(value *string, key string) {
+	if v := os.Getenv(key); v != "" {
+		*value = v
+	}
+}
+
+// missingSource describes where a missing setting was looked for.
+func missingSource(setting, env, key, profile, path string) string {
+	if path == "" {
+		return fmt.Sprintf("no %s found, set %s or add a profile to the config file", setting, env)
+	}
+	return fmt.Sprintf("no %s found, set %s or %s in profile %q of %s", setting, env, key, profile, path)
+}
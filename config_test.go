@@ -0,0 +1,143 @@
+package glesys
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testConfigFile = `default_profile: production
+profiles:
+  production:
+    project: CL12345
+    api_key: production-key
+  staging:
+    project: CL67890
+    api_key: staging-key
+    api_url: https://dev-api.glesys.test
+    user_agent: staging-tool/0.0.1
+`
+
+func writeTestConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func clearConfigEnv(t *testing.T) {
+	for _, key := range []string{EnvProject, EnvAPIKey, EnvAPIURL, EnvUserAgent, EnvProfile, EnvConfigFile} {
+		t.Setenv(key, "")
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+}
+
+func TestLoadConfigFromEnvironment(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv(EnvProject, "CL12345")
+	t.Setenv(EnvAPIKey, "api-key")
+	t.Setenv(EnvAPIURL, "https://dev-api.glesys.test")
+	t.Setenv(EnvUserAgent, "test-application/0.0.1")
+
+	config, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{Project: "CL12345", APIKey: "api-key", APIURL: "https://dev-api.glesys.test", UserAgent: "test-application/0.0.1"}, config)
+
+	client, err := NewClientFromEnvironment()
+	assert.NoError(t, err)
+	assert.Equal(t, "CL12345", client.project)
+	assert.Equal(t, "https://dev-api.glesys.test", client.BaseURL.String(), "api url is used")
+	assert.Equal(t, "test-application/0.0.1", client.userAgent, "user agent is used")
+}
+
+func TestLoadConfigFromProfiles(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeTestConfig(t, testConfigFile)
+
+	config, err := ConfigLoader{Path: path}.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "production", config.Profile, "default_profile is used")
+	assert.Equal(t, "production-key", config.APIKey)
+	assert.Equal(t, path, config.Path)
+
+	config, err = ConfigLoader{Path: path, Profile: "staging"}.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "CL67890", config.Project, "profile is selected")
+	assert.Equal(t, "https://dev-api.glesys.test", config.APIURL)
+
+	t.Setenv(EnvProfile, "staging")
+	t.Setenv(EnvConfigFile, path)
+	config, err = LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "staging", config.Profile, "profile is selected by environment")
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeTestConfig(t, testConfigFile)
+	t.Setenv(EnvAPIKey, "env-key")
+	t.Setenv(EnvUserAgent, "env-tool/0.0.1")
+
+	config, err := ConfigLoader{Path: path, Profile: "staging"}.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "CL67890", config.Project, "profile is used for unset variables")
+	assert.Equal(t, "env-key", config.APIKey, "environment takes precedence over profile")
+	assert.Equal(t, "env-tool/0.0.1", config.UserAgent, "environment takes precedence over profile")
+
+	client, err := config.NewClient(WithUserAgent("option-tool/0.0.1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "option-tool/0.0.1", client.userAgent, "options take precedence over config")
+}
+
+func TestLoadConfigDefaultPath(t *testing.T) {
+	clearConfigEnv(t)
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "glesys"), 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "glesys", "config.yaml"), []byte(testConfigFile), 0o600))
+
+	config, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "CL12345", config.Project, "config file is read from config dir")
+}
+
+func TestLoadConfigWithoutHomeDirectory(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "")
+	t.Setenv(EnvProject, "CL12345")
+	t.Setenv(EnvAPIKey, "api-key")
+
+	config, err := LoadConfig()
+	assert.NoError(t, err, "environment is used without a home directory")
+	assert.Equal(t, &Config{Project: "CL12345", APIKey: "api-key"}, config)
+
+	_, err = ConfigLoader{Profile: "staging"}.Load()
+	assert.ErrorContains(t, err, `no config file found for profile "staging"`, "explicit profile requires a config file")
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	clearConfigEnv(t)
+
+	_, err := LoadConfig()
+	assert.ErrorIs(t, err, ErrMissingConfig)
+	assert.ErrorContains(t, err, "no project found, set GLESYS_PROJECT")
+	assert.ErrorContains(t, err, "no API key found, set GLESYS_API_KEY")
+
+	t.Setenv(EnvProject, "CL12345")
+	path := writeTestConfig(t, "profiles:\n  other:\n    project: CL67890\n")
+	_, err = ConfigLoader{Path: path}.Load()
+	assert.ErrorIs(t, err, ErrMissingConfig)
+	assert.NotContains(t, err.Error(), "no project found", "project is set")
+	assert.ErrorContains(t, err, `set GLESYS_API_KEY or api_key in profile "default" of `+path)
+
+	_, err = ConfigLoader{Path: path, Profile: "unknown"}.Load()
+	assert.ErrorContains(t, err, `profile "unknown" not found`)
+
+	_, err = ConfigLoader{Path: filepath.Join(t.TempDir(), "missing.yaml")}.Load()
+	assert.ErrorContains(t, err, "reading config file", "explicit config file has to exist")
+
+	_, err = ConfigLoader{Path: writeTestConfig(t, "profiles: [")}.Load()
+	assert.ErrorContains(t, err, "reading config file", "invalid config file returns an error")
+}
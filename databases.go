@@ -5,6 +5,20 @@ import (
 	"fmt"
 )
 
+// DatabaseAPI is the interface implemented by DatabaseService.
+type DatabaseAPI interface {
+	Create(ctx context.Context, params CreateDatabaseParams) (*DatabaseDetails, error)
+	Delete(ctx context.Context, databaseID string) error
+	UpdateAllowlist(ctx context.Context, params UpdateAllowlistParams) (*DatabaseDetails, error)
+	List(ctx context.Context) (*[]Database, error)
+	Details(ctx context.Context, databaseID string) (*DatabaseDetails, error)
+	ConnectionString(ctx context.Context, databaseID string) (*ConnectionDetails, error)
+	ListPlans(ctx context.Context) (*[]DatabasePlan, error)
+	EstimatedCost(ctx context.Context, params EstimatedCostParams) (*Billing, error)
+}
+
+var _ DatabaseAPI = (*DatabaseService)(nil)
+
 // DatabaseService provides functions to interact with Databases
 type DatabaseService struct {
 	client clientInterface
@@ -4,6 +4,29 @@ import (
 	"context"
 )
 
+// DNSDomainAPI is the interface implemented by DNSDomainService.
+type DNSDomainAPI interface {
+	Available(ctx context.Context, search string) (*[]DNSDomain, error)
+	AddDNSDomain(ctx context.Context, params AddDNSDomainParams) (*DNSDomain, error)
+	Delete(ctx context.Context, params DeleteDNSDomainParams) error
+	Details(ctx context.Context, domainname string) (*DNSDomain, error)
+	Edit(ctx context.Context, params EditDNSDomainParams) (*DNSDomain, error)
+	Export(ctx context.Context, domainname string) (string, error)
+	List(ctx context.Context) (*[]DNSDomain, error)
+	GenerateAuthCode(ctx context.Context, domainname string) (string, error)
+	Register(ctx context.Context, params RegisterDNSDomainParams) (*DNSDomain, error)
+	Renew(ctx context.Context, params RenewDNSDomainParams) (*DNSDomain, error)
+	SetAutoRenew(ctx context.Context, params SetAutoRenewParams) (*DNSDomain, error)
+	Transfer(ctx context.Context, params RegisterDNSDomainParams) (*DNSDomain, error)
+	ListRecords(ctx context.Context, domainname string) (*[]DNSDomainRecord, error)
+	AddRecord(ctx context.Context, params AddRecordParams) (*DNSDomainRecord, error)
+	UpdateRecord(ctx context.Context, params UpdateRecordParams) (*DNSDomainRecord, error)
+	DeleteRecord(ctx context.Context, recordID int) error
+	ChangeNameservers(ctx context.Context, params ChangeNameserverParams) error
+}
+
+var _ DNSDomainAPI = (*DNSDomainService)(nil)
+
 // DNSDomainService provides functions to interact with dns domains
 type DNSDomainService struct {
 	client clientInterface
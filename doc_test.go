@@ -2,7 +2,9 @@ package glesys_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	glesys "github.com/glesys/glesys-go/v8"
 )
@@ -36,6 +38,22 @@ func ExampleUserService_DoOTPLogin() {
 	}
 }
 
+func ExampleNewClientWithOptions() {
+	client, err := glesys.NewClientWithOptions("CL12345", "your-api-key",
+		glesys.WithUserAgent("my-application/0.0.1"),
+		glesys.WithTimeout(30*time.Second),
+		glesys.WithRetryPolicy(glesys.DefaultRetryPolicy()),
+	)
+	if err != nil {
+		fmt.Printf("Error creating client %s\n", err)
+		return
+	}
+
+	servers, _ := client.Servers.List(context.Background())
+
+	fmt.Printf("%#v\n", servers)
+}
+
 func ExampleEmailDomainService_Overview() {
 	client := glesys.NewClient("CL12345", "your-api-key", "my-application/0.0.1")
 
@@ -913,3 +931,19 @@ func ExampleDatabaseService_EstimatedCost() {
 
 	fmt.Printf("%#v\n", billing)
 }
+
+func ExampleAPIError() {
+	client := glesys.NewClient("CL12345", "your-api-key", "my-application/0.0.1")
+
+	_, err := client.Servers.Details(context.Background(), "kvm123456")
+
+	var apiErr *glesys.APIError
+	switch {
+	case errors.Is(err, glesys.ErrNotFound):
+		fmt.Println("server does not exist")
+	case errors.Is(err, glesys.ErrLocked):
+		fmt.Println("server is locked, try again later")
+	case errors.As(err, &apiErr):
+		fmt.Printf("%s failed: %d %s\n", apiErr.Path, apiErr.Code, apiErr.Text)
+	}
+}
@@ -6,6 +6,22 @@ import (
 	"strings"
 )
 
+// EmailDomainAPI is the interface implemented by EmailDomainService.
+type EmailDomainAPI interface {
+	Overview(ctx context.Context, params OverviewParams) (*EmailOverview, error)
+	List(ctx context.Context, domain string, params ListEmailsParams) (*EmailList, error)
+	EditAccount(ctx context.Context, emailAccount string, params EditAccountParams) (*EmailAccount, error)
+	Delete(ctx context.Context, email string) error
+	CreateAccount(ctx context.Context, params CreateAccountParams) (*EmailAccount, error)
+	Quota(ctx context.Context, emailaccount string) (*EmailQuota, error)
+	CreateAlias(ctx context.Context, params EmailAliasParams) (*EmailAlias, error)
+	EditAlias(ctx context.Context, params EmailAliasParams) (*EmailAlias, error)
+	Costs(ctx context.Context) (*EmailCosts, error)
+	ResetPassword(ctx context.Context, emailaccount string) (string, error)
+}
+
+var _ EmailDomainAPI = (*EmailDomainService)(nil)
+
 // EmailDomainService provides functions to interact with the Email api
 type EmailDomainService struct {
 	client clientInterface
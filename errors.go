@@ -0,0 +1,204 @@
+package glesys
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Sentinel errors which an *APIError can be matched against using errors.Is.
+var (
+	// ErrUnauthorized is matched by 401 and 403 responses.
+	ErrUnauthorized = errors.New("glesys: unauthorized")
+	// ErrNotFound is matched by 404 responses.
+	ErrNotFound = errors.New("glesys: not found")
+	// ErrRateLimited is matched by 429 responses.
+	ErrRateLimited = errors.New("glesys: rate limited")
+	// ErrLocked is matched by 423 responses and errors about locked resources.
+	ErrLocked = errors.New("glesys: resource locked")
+	// ErrValidation is matched by 400 and 422 responses.
+	ErrValidation = errors.New("glesys: validation failed")
+)
+
+// maxErrorBodySize limits how much of a non-JSON error body is kept.
+const maxErrorBodySize = 512
+
+// APIError is returned when the GleSYS API responds with an error.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Code is the status code reported by the API in `response.status.code`.
+	Code int
+	// Text is the status text reported by the API in `response.status.text`.
+	Text string
+	// Debug is the debug block of the response, if any.
+	Debug map[string]any
+	// Path is the API path of the failed request, e.g. `server/create`.
+	Path string
+	// RequestID identifies the request, if provided by the API.
+	RequestID string
+	// Body contains the beginning of the response body when it could not be
+	// parsed as JSON, e.g. an HTML page from a proxy.
+	Body string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request failed with HTTP error: %v (%v)", e.StatusCode, e.Text)
+}
+
+// Is makes it possible to match an *APIError against the sentinel errors
+// using errors.Is.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.hasCode(http.StatusUnauthorized, http.StatusForbidden)
+	case ErrNotFound:
+		return e.hasCode(http.StatusNotFound)
+	case ErrRateLimited:
+		return e.hasCode(http.StatusTooManyRequests)
+	case ErrLocked:
+		return e.hasCode(http.StatusLocked) || isLockedText(e.Text)
+	case ErrValidation:
+		code := e.Code
+		if code == 0 {
+			code = e.StatusCode
+		}
+		return (code == http.StatusBadRequest || code == http.StatusUnprocessableEntity) && !e.Is(ErrLocked)
+	}
+	return false
+}
+
+// lockedWord matches the word "locked", optionally negated, but not words
+// such as "unlocked" or "blocked".
+var lockedWord = regexp.MustCompile(`(?i)\b(not\s+)?locked\b`)
+
+// isLockedText returns true if text says that a resource is locked.
+func isLockedText(text string) bool {
+	for _, match := range lockedWord.FindAllStringSubmatch(text, -1) {
+		if match[1] == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCode returns true if either the HTTP or the API status code matches.
+func (e *APIError) hasCode(codes ...int) bool {
+	for _, code := range codes {
+		if e.StatusCode == code || e.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func handleResponseError(response *http.Response) error {
+	apiErr := &APIError{
+		StatusCode: response.StatusCode,
+		RequestID:  response.Header.Get("X-Request-Id"),
+	}
+
+	if response.Request != nil && response.Request.URL != nil {
+		apiErr.Path = strings.Trim(response.Request.URL.Path, "/")
+	}
+
+	data := struct {
+		Response struct {
+			Status struct {
+				Code          int    `json:"code"`
+				Text          string `json:"text"`
+				TransactionID any    `json:"transactionid"`
+			} `json:"status"`
+			Debug map[string]any `json:"debug"`
+		} `json:"response"`
+	}{}
+
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, &data); err != nil {
+		apiErr.Text = http.StatusText(response.StatusCode)
+		apiErr.Body = strings.TrimSpace(string(body))
+		if len(apiErr.Body) > maxErrorBodySize {
+			apiErr.Body = apiErr.Body[:maxErrorBodySize]
+		}
+		return apiErr
+	}
+
+	apiErr.Code = data.Response.Status.Code
+	apiErr.Text = strings.TrimSpace(data.Response.Status.Text)
+	if apiErr.Text == "" {
+		apiErr.Text = http.StatusText(response.StatusCode)
+	}
+	apiErr.Debug = data.Response.Debug
+	if id := data.Response.Status.TransactionID; id != nil {
+		apiErr.RequestID = fmt.Sprint(id)
+	}
+	return apiErr
+}
+
+// withPath sets the API path of err if it is an *APIError.
+func withPath(err error, path string) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		apiErr.Path = path
+	}
+	return err
+}
+
+// FieldError describes an invalid parameter.
+type FieldError struct {
+	// Field is the name of the field, e.g. "Storage".
+	Field string
+	// Message describes the problem.
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ParamsError is returned when parameters are found to be invalid before
+// they are sent to the API. It matches ErrValidation using errors.Is.
+type ParamsError struct {
+	Errors []FieldError
+}
+
+func (e *ParamsError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldError := range e.Errors {
+		messages[i] = fieldError.Error()
+	}
+	return "glesys: invalid parameters: " + strings.Join(messages, "; ")
+}
+
+// Is makes it possible to match a *ParamsError against ErrValidation using
+// errors.Is.
+func (e *ParamsError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Field returns the error of the field, if any.
+func (e *ParamsError) Field(field string) (FieldError, bool) {
+	for _, fieldError := range e.Errors {
+		if fieldError.Field == field {
+			return fieldError, true
+		}
+	}
+	return FieldError{}, false
+}
+
+// paramsError returns a *ParamsError for errs, or nil if there are none.
+func paramsError(errs []FieldError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ParamsError{Errors: errs}
+}
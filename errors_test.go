@@ -0,0 +1,92 @@
+package glesys
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleResponseErrorReturnsAPIError(t *testing.T) {
+	json := `{ "response": {
+		"status": { "code": 404, "text": "Server not found", "transactionid": "abc-123" },
+		"debug": { "input": { "serverid": "kvm123456" } } } }`
+	response := http.Response{
+		Body:       io.NopCloser(bytes.NewBufferString(json)),
+		StatusCode: 404,
+	}
+
+	err := handleResponseError(&response)
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr), "error is an *APIError")
+	assert.Equal(t, 404, apiErr.StatusCode, "StatusCode is correct")
+	assert.Equal(t, 404, apiErr.Code, "Code is correct")
+	assert.Equal(t, "Server not found", apiErr.Text, "Text is correct")
+	assert.Equal(t, "abc-123", apiErr.RequestID, "RequestID is correct")
+	assert.Equal(t, map[string]any{"serverid": "kvm123456"}, apiErr.Debug["input"], "Debug is correct")
+	assert.Equal(t, "request failed with HTTP error: 404 (Server not found)", err.Error(), "error message is correct")
+}
+
+func TestHandleResponseErrorWithHTMLBody(t *testing.T) {
+	response := http.Response{
+		Body:       io.NopCloser(bytes.NewBufferString("<html><body>Bad Gateway</body></html>")),
+		StatusCode: 502,
+		Header:     http.Header{"X-Request-Id": []string{"req-1"}},
+	}
+
+	err := handleResponseError(&response)
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr), "error is an *APIError")
+	assert.Equal(t, "request failed with HTTP error: 502 (Bad Gateway)", err.Error(), "error message is correct")
+	assert.Equal(t, "<html><body>Bad Gateway</body></html>", apiErr.Body, "Body is correct")
+	assert.Equal(t, "req-1", apiErr.RequestID, "RequestID is correct")
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		err    *APIError
+		target error
+	}{
+		{&APIError{StatusCode: 401}, ErrUnauthorized},
+		{&APIError{StatusCode: 403}, ErrUnauthorized},
+		{&APIError{StatusCode: 404}, ErrNotFound},
+		{&APIError{StatusCode: 400, Code: 404}, ErrNotFound},
+		{&APIError{StatusCode: 429}, ErrRateLimited},
+		{&APIError{StatusCode: 423}, ErrLocked},
+		{&APIError{StatusCode: 400, Code: 400, Text: "The server is locked."}, ErrLocked},
+		{&APIError{StatusCode: 400, Code: 400, Text: "Invalid hostname"}, ErrValidation},
+		{&APIError{StatusCode: 422}, ErrValidation},
+	}
+
+	for _, test := range tests {
+		assert.ErrorIs(t, test.err, test.target, "%v matches %v", test.err, test.target)
+	}
+
+	assert.NotErrorIs(t, &APIError{StatusCode: 404}, ErrUnauthorized)
+	assert.NotErrorIs(t, &APIError{StatusCode: 400, Text: "The server is locked."}, ErrValidation)
+
+	for _, text := range []string{"The server is not locked.", "The server was unlocked.", "Port is blocked"} {
+		err := &APIError{StatusCode: 400, Code: 400, Text: text}
+		assert.NotErrorIs(t, err, ErrLocked, "%q is not locked", text)
+		assert.ErrorIs(t, err, ErrValidation, "%q is a validation error", text)
+	}
+}
+
+func TestDoReturnsAPIErrorWithPath(t *testing.T) {
+	payload := `{ "response": { "status": { "code": 401, "text": "Access denied" } } }`
+	client := NewClient("project-id", "api-key", "")
+	client.httpClient = &mockHTTPClient{body: payload, statusCode: 401}
+
+	err := client.post(context.Background(), "server/create", nil, CreateServerParams{})
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr), "error is an *APIError")
+	assert.Equal(t, "server/create", apiErr.Path, "Path is correct")
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
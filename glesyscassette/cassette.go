@@ -0,0 +1,332 @@
+// Package glesyscassette provides an http.RoundTripper which records API
+// interactions to a cassette file and replays them in tests.
+//
+// In record mode, requests are sent to the API and every request/response
+// pair is appended to a JSON Lines file. The Authorization header is never
+// stored and secrets such as passwords and API keys are scrubbed from the
+// bodies. In replay mode, responses are served from the cassette and requests
+// without a recorded interaction fail.
+//
+//	recorder, err := glesyscassette.New("testdata/servers.jsonl", glesyscassette.ModeReplay)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer recorder.Close()
+//
+//	client, err := glesys.NewClientWithOptions("CL12345", "your-api-key",
+//		glesys.WithHTTPClient(&http.Client{Transport: recorder}),
+//	)
+package glesyscassette
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/glesys/glesys-go/v8/internal/redact"
+)
+
+// Mode is the mode of a Recorder.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the API and records them to the cassette.
+	ModeRecord
+)
+
+// Interaction is a recorded request/response pair.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request stored in a cassette.
+type RecordedRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response stored in a cassette.
+type RecordedResponse struct {
+	StatusCode int               `json:"status"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       json.RawMessage   `json:"body,omitempty"`
+}
+
+// ErrNoInteraction is returned in replay mode when no recorded interaction
+// matches a request.
+var ErrNoInteraction = errors.New("glesyscassette: no recorded interaction")
+
+// recordedHeaders are the response headers stored in a cassette.
+var recordedHeaders = []string{"Content-Type", "Retry-After", "X-Request-Id"}
+
+// Recorder records or replays API interactions. It is safe for concurrent
+// use.
+type Recorder struct {
+	mode      Mode
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	file         *os.File
+	interactions []Interaction
+	used         []bool
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithTransport sets the transport used to send requests in record mode.
+// Defaults to http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// New creates a Recorder for the cassette at path. In record mode the
+// cassette is truncated, in replay mode it is loaded.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{mode: mode, transport: http.DefaultTransport}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	switch mode {
+	case ModeRecord:
+		file, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		r.file = file
+	case ModeReplay:
+		interactions, err := load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.interactions = interactions
+		r.used = make([]bool, len(interactions))
+	default:
+		return nil, fmt.Errorf("glesyscassette: unknown mode %d", mode)
+	}
+
+	return r, nil
+}
+
+// Close closes the cassette file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// Interactions returns the interactions of the cassette. In record mode
+// these are the interactions recorded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Unused returns the interactions which have not been replayed yet. It can be
+// used to assert that a test made all the expected calls.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	recorded, err := newRecordedRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(request, recorded)
+	}
+	return r.replay(request, recorded)
+}
+
+func (r *Recorder) record(request *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	response, err := r.transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction := Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Headers:    map[string]string{},
+			Body:       scrub(body),
+		},
+	}
+	for _, header := range recordedHeaders {
+		if value := response.Header.Get(header); value != "" {
+			interaction.Response.Headers[header] = value
+		}
+	}
+
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil, errors.New("glesyscassette: recorder is closed")
+	}
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		return nil, err
+	}
+	r.interactions = append(r.interactions, interaction)
+	r.used = append(r.used, true)
+
+	return response, nil
+}
+
+func (r *Recorder) replay(request *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		header := http.Header{}
+		for key, value := range interaction.Response.Headers {
+			header.Set(key, value)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       request,
+		}, nil
+	}
+
+	if len(recorded.Body) == 0 {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, recorded.Method, recorded.Path)
+	}
+	return nil, fmt.Errorf("%w for %s %s %s", ErrNoInteraction, recorded.Method, recorded.Path, recorded.Body)
+}
+
+// newRecordedRequest reads and restores the body of request and returns its
+// scrubbed and normalized representation.
+func newRecordedRequest(request *http.Request) (RecordedRequest, error) {
+	recorded := RecordedRequest{
+		Method: request.Method,
+		Path:   strings.Trim(request.URL.Path, "/"),
+	}
+
+	if request.Body != nil && request.Body != http.NoBody {
+		body, err := io.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return recorded, err
+		}
+		request.Body = io.NopCloser(bytes.NewReader(body))
+		recorded.Body = scrub(body)
+	}
+
+	return recorded, nil
+}
+
+// matches returns true if a recorded request matches the method, path and
+// normalized JSON body of a request.
+func matches(recorded, request RecordedRequest) bool {
+	return recorded.Method == request.Method &&
+		recorded.Path == request.Path &&
+		bytes.Equal(normalize(recorded.Body), normalize(request.Body))
+}
+
+// scrub returns a body with all secrets redacted, suitable for storing in a
+// cassette. Bodies which are not JSON are stored as JSON strings.
+func scrub(body []byte) json.RawMessage {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil
+	}
+	out, ok := redact.JSON(body)
+	if !ok {
+		out, _ = json.Marshal(string(body))
+	}
+	return out
+}
+
+// normalize returns the canonical encoding of a JSON body, so that key order
+// and whitespace do not affect matching.
+func normalize(body json.RawMessage) []byte {
+	var v interface{}
+	if len(body) == 0 || json.Unmarshal(body, &v) != nil {
+		return body
+	}
+	out, _ := json.Marshal(v)
+	return out
+}
+
+// load reads the interactions of a cassette file.
+func load(path string) ([]Interaction, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var interactions []Interaction
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("glesyscassette: %s:%d: %w", path, line, err)
+		}
+		interactions = append(interactions, interaction)
+	}
+	return interactions, scanner.Err()
+}
@@ -0,0 +1,122 @@
+package glesyscassette
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	glesys "github.com/glesys/glesys-go/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/server/list":
+			w.Write([]byte(`{ "response": { "servers": [{ "serverid": "kvm12345", "hostname": "web-01" }] } }`))
+		case "/server/create":
+			w.Write([]byte(`{ "response": { "server": { "serverid": "kvm23456", "hostname": "web-02" } } }`))
+		case "/objectstorage/createcredential":
+			w.Write([]byte(`{ "response": { "credential": { "accesskey": "access", "secretkey": "s3-secret" } } }`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{ "response": { "status": { "code": 404, "text": "Not found" } } }`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestClient(t *testing.T, baseURL string, recorder *Recorder) *glesys.Client {
+	client, err := glesys.NewClientWithOptions("CL12345", "super-secret-api-key",
+		glesys.WithBaseURL(baseURL),
+		glesys.WithHTTPClient(&http.Client{Transport: recorder}),
+	)
+	assert.NoError(t, err)
+	return client
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	server := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	recorder, err := New(path, ModeRecord)
+	assert.NoError(t, err)
+	client := newTestClient(t, server.URL, recorder)
+
+	servers, err := client.Servers.List(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "kvm12345", (*servers)[0].ID, "recorded response is returned")
+	_, err = client.Servers.Create(context.Background(), glesys.CreateServerParams{Hostname: "web-02", Password: "root-secret"})
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Close())
+	assert.Len(t, recorder.Interactions(), 2, "interactions are recorded")
+
+	server.Close()
+
+	recorder, err = New(path, ModeReplay)
+	assert.NoError(t, err)
+	client = newTestClient(t, "http://replay.invalid", recorder)
+
+	servers, err = client.Servers.List(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "web-01", (*servers)[0].Hostname, "response is replayed")
+	assert.Len(t, recorder.Unused(), 1)
+
+	created, err := client.Servers.Create(context.Background(), glesys.CreateServerParams{Hostname: "web-02", Password: "another-secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, "kvm23456", created.ID, "request with scrubbed secret is matched")
+	assert.Empty(t, recorder.Unused(), "all interactions are used")
+}
+
+func TestRecordScrubsSecrets(t *testing.T) {
+	server := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	recorder, err := New(path, ModeRecord)
+	assert.NoError(t, err)
+	client := newTestClient(t, server.URL, recorder)
+
+	client.Servers.Create(context.Background(), glesys.CreateServerParams{Hostname: "web-02", Password: "root-secret"})
+	client.ObjectStorages.CreateCredential(context.Background(), glesys.CreateObjectStorageCredentialParams{InstanceID: "os-123"})
+	recorder.Close()
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"path":"server/create"`, "path is recorded")
+	assert.NotContains(t, string(content), "root-secret", "request secrets are scrubbed")
+	assert.NotContains(t, string(content), "s3-secret", "response secrets are scrubbed")
+	assert.NotContains(t, string(content), "super-secret-api-key", "api key is not recorded")
+	assert.NotContains(t, string(content), "Authorization", "authorization header is not recorded")
+}
+
+func TestReplayFailsOnUnmatchedRequest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	os.WriteFile(path, []byte(`{"request":{"method":"GET","path":"server/details/serverid/kvm12345/includestate/yes"},"response":{"status":200,"body":{"response":{"server":{"serverid":"kvm12345"}}}}}`+"\n"), 0o600)
+
+	recorder, err := New(path, ModeReplay)
+	assert.NoError(t, err)
+	client := newTestClient(t, "http://replay.invalid", recorder)
+
+	_, err = client.Servers.Details(context.Background(), "kvm99999")
+	assert.ErrorIs(t, err, ErrNoInteraction, "request with other path is not matched")
+
+	details, err := client.Servers.Details(context.Background(), "kvm12345")
+	assert.NoError(t, err)
+	assert.Equal(t, "kvm12345", details.ID, "request is matched")
+
+	_, err = client.Servers.Details(context.Background(), "kvm12345")
+	assert.ErrorIs(t, err, ErrNoInteraction, "interactions are only replayed once")
+}
+
+func TestMatchesNormalizedBody(t *testing.T) {
+	recorded := RecordedRequest{Method: "POST", Path: "server/details", Body: []byte(`{"serverid":"kvm1","includestate":true}`)}
+
+	assert.True(t, matches(recorded, RecordedRequest{Method: "POST", Path: "server/details", Body: []byte(`{ "includestate": true, "serverid": "kvm1" }`)}))
+	assert.False(t, matches(recorded, RecordedRequest{Method: "GET", Path: "server/details", Body: recorded.Body}))
+	assert.False(t, matches(recorded, RecordedRequest{Method: "POST", Path: "server/list", Body: recorded.Body}))
+}
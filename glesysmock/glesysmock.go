@@ -0,0 +1,86 @@
+// Package glesysmock provides programmable fakes of the glesys service
+// interfaces for unit tests.
+//
+// Every fake records its calls and their parameters. The behaviour of a
+// method is programmed by setting the corresponding Func field, methods
+// without a function return zero values. The fakes replace the services of
+// glesys.Services, which is accepted by the code under test instead of a
+// glesys.Client:
+//
+//	servers := &glesysmock.ServerAPI{}
+//	servers.CreateFunc = func(ctx context.Context, params glesys.CreateServerParams) (*glesys.ServerDetails, error) {
+//		return &glesys.ServerDetails{ID: "kvm12345", Hostname: params.Hostname}, nil
+//	}
+//
+//	services := client.Services()
+//	services.Servers = servers
+//
+//	// ... exercise the code under test ...
+//
+//	params := servers.CallsTo("Create")[0].Params().(glesys.CreateServerParams)
+package glesysmock
+
+//go:generate go run ../internal/cmd/mockgen -src .. -o mocks.go
+
+import "sync"
+
+// Call is a recorded call to a fake.
+type Call struct {
+	// Method is the name of the called method.
+	Method string
+	// Args are the arguments of the call, except the context.
+	Args []interface{}
+}
+
+// Params returns the last argument of the call, which is the params struct
+// for methods which take one.
+func (c Call) Params() interface{} {
+	if len(c.Args) == 0 {
+		return nil
+	}
+	return c.Args[len(c.Args)-1]
+}
+
+// Recorder records the calls to a fake. It is embedded in all fakes and is
+// safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns all recorded calls in order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the recorded calls to method in order.
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset removes all recorded calls.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
+
+func (r *Recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
@@ -0,0 +1,60 @@
+package glesysmock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	glesys "github.com/glesys/glesys-go/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeRecordsCalls(t *testing.T) {
+	servers := &ServerAPI{}
+	servers.CreateFunc = func(ctx context.Context, params glesys.CreateServerParams) (*glesys.ServerDetails, error) {
+		return &glesys.ServerDetails{ID: "kvm12345", Hostname: params.Hostname}, nil
+	}
+
+	services := glesys.NewClient("CL12345", "api-key", "").Services()
+	services.Servers = servers
+
+	server, err := services.Servers.Create(context.Background(), glesys.CreateServerParams{Hostname: "web-01"})
+	assert.NoError(t, err)
+	assert.Equal(t, "kvm12345", server.ID, "programmed function is called")
+
+	services.Servers.Stop(context.Background(), "kvm12345", glesys.StopServerParams{Type: "reboot"})
+
+	assert.Len(t, servers.Calls(), 2)
+	assert.Equal(t, glesys.CreateServerParams{Hostname: "web-01"}, servers.CallsTo("Create")[0].Params(), "params are recorded")
+	assert.Equal(t, []interface{}{"kvm12345", glesys.StopServerParams{Type: "reboot"}}, servers.CallsTo("Stop")[0].Args, "arguments are recorded")
+
+	servers.Reset()
+	assert.Empty(t, servers.Calls(), "calls are reset")
+}
+
+func TestFakeDefaults(t *testing.T) {
+	ips := &IPAPI{}
+
+	list, err := ips.Reserved(context.Background(), glesys.ReservedIPsParams{})
+	assert.NoError(t, err)
+	assert.NotNil(t, list, "pointers are not nil")
+	assert.Empty(t, *list)
+
+	ips.ReleaseFunc = func(ctx context.Context, ipAddress string) error {
+		return glesys.ErrNotFound
+	}
+	assert.True(t, errors.Is(ips.Release(context.Background(), "192.0.2.1"), glesys.ErrNotFound), "programmed error is returned")
+	assert.Nil(t, (&LoadBalancerAPI{}).CallsTo("Create"))
+}
+
+func TestFakeWithWaiter(t *testing.T) {
+	servers := &ServerAPI{}
+	servers.DetailsFunc = func(ctx context.Context, serverID string) (*glesys.ServerDetails, error) {
+		return &glesys.ServerDetails{ID: serverID, IsRunning: true, State: "running"}, nil
+	}
+
+	server, err := glesys.WaitUntilServerRunning(context.Background(), servers, "kvm12345")
+	assert.NoError(t, err)
+	assert.Equal(t, "running", server.State, "waiter uses the programmed details")
+	assert.Len(t, servers.CallsTo("Details"), 1)
+}
@@ -0,0 +1,1422 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package glesysmock
+
+import (
+	"context"
+
+	glesys "github.com/glesys/glesys-go/v8"
+)
+
+// DNSDomainAPI is a programmable fake of glesys.DNSDomainAPI.
+type DNSDomainAPI struct {
+	Recorder
+
+	// AvailableFunc is called by Available if set.
+	AvailableFunc func(ctx context.Context, search string) (*[]glesys.DNSDomain, error)
+	// AddDNSDomainFunc is called by AddDNSDomain if set.
+	AddDNSDomainFunc func(ctx context.Context, params glesys.AddDNSDomainParams) (*glesys.DNSDomain, error)
+	// DeleteFunc is called by Delete if set.
+	DeleteFunc func(ctx context.Context, params glesys.DeleteDNSDomainParams) error
+	// DetailsFunc is called by Details if set.
+	DetailsFunc func(ctx context.Context, domainname string) (*glesys.DNSDomain, error)
+	// EditFunc is called by Edit if set.
+	EditFunc func(ctx context.Context, params glesys.EditDNSDomainParams) (*glesys.DNSDomain, error)
+	// ExportFunc is called by Export if set.
+	ExportFunc func(ctx context.Context, domainname string) (string, error)
+	// ListFunc is called by List if set.
+	ListFunc func(ctx context.Context) (*[]glesys.DNSDomain, error)
+	// GenerateAuthCodeFunc is called by GenerateAuthCode if set.
+	GenerateAuthCodeFunc func(ctx context.Context, domainname string) (string, error)
+	// RegisterFunc is called by Register if set.
+	RegisterFunc func(ctx context.Context, params glesys.RegisterDNSDomainParams) (*glesys.DNSDomain, error)
+	// RenewFunc is called by Renew if set.
+	RenewFunc func(ctx context.Context, params glesys.RenewDNSDomainParams) (*glesys.DNSDomain, error)
+	// SetAutoRenewFunc is called by SetAutoRenew if set.
+	SetAutoRenewFunc func(ctx context.Context, params glesys.SetAutoRenewParams) (*glesys.DNSDomain, error)
+	// TransferFunc is called by Transfer if set.
+	TransferFunc func(ctx context.Context, params glesys.RegisterDNSDomainParams) (*glesys.DNSDomain, error)
+	// ListRecordsFunc is called by ListRecords if set.
+	ListRecordsFunc func(ctx context.Context, domainname string) (*[]glesys.DNSDomainRecord, error)
+	// AddRecordFunc is called by AddRecord if set.
+	AddRecordFunc func(ctx context.Context, params glesys.AddRecordParams) (*glesys.DNSDomainRecord, error)
+	// UpdateRecordFunc is called by UpdateRecord if set.
+	UpdateRecordFunc func(ctx context.Context, params glesys.UpdateRecordParams) (*glesys.DNSDomainRecord, error)
+	// DeleteRecordFunc is called by DeleteRecord if set.
+	DeleteRecordFunc func(ctx context.Context, recordID int) error
+	// ChangeNameserversFunc is called by ChangeNameservers if set.
+	ChangeNameserversFunc func(ctx context.Context, params glesys.ChangeNameserverParams) error
+}
+
+var _ glesys.DNSDomainAPI = (*DNSDomainAPI)(nil)
+
+// Available records the call and calls AvailableFunc.
+func (m *DNSDomainAPI) Available(ctx context.Context, search string) (*[]glesys.DNSDomain, error) {
+	m.record("Available", search)
+	if m.AvailableFunc != nil {
+		return m.AvailableFunc(ctx, search)
+	}
+	return new([]glesys.DNSDomain), nil
+}
+
+// AddDNSDomain records the call and calls AddDNSDomainFunc.
+func (m *DNSDomainAPI) AddDNSDomain(ctx context.Context, params glesys.AddDNSDomainParams) (*glesys.DNSDomain, error) {
+	m.record("AddDNSDomain", params)
+	if m.AddDNSDomainFunc != nil {
+		return m.AddDNSDomainFunc(ctx, params)
+	}
+	return new(glesys.DNSDomain), nil
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *DNSDomainAPI) Delete(ctx context.Context, params glesys.DeleteDNSDomainParams) error {
+	m.record("Delete", params)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, params)
+	}
+	return nil
+}
+
+// Details records the call and calls DetailsFunc.
+func (m *DNSDomainAPI) Details(ctx context.Context, domainname string) (*glesys.DNSDomain, error) {
+	m.record("Details", domainname)
+	if m.DetailsFunc != nil {
+		return m.DetailsFunc(ctx, domainname)
+	}
+	return new(glesys.DNSDomain), nil
+}
+
+// Edit records the call and calls EditFunc.
+func (m *DNSDomainAPI) Edit(ctx context.Context, params glesys.EditDNSDomainParams) (*glesys.DNSDomain, error) {
+	m.record("Edit", params)
+	if m.EditFunc != nil {
+		return m.EditFunc(ctx, params)
+	}
+	return new(glesys.DNSDomain), nil
+}
+
+// Export records the call and calls ExportFunc.
+func (m *DNSDomainAPI) Export(ctx context.Context, domainname string) (string, error) {
+	m.record("Export", domainname)
+	if m.ExportFunc != nil {
+		return m.ExportFunc(ctx, domainname)
+	}
+	return "", nil
+}
+
+// List records the call and calls ListFunc.
+func (m *DNSDomainAPI) List(ctx context.Context) (*[]glesys.DNSDomain, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return new([]glesys.DNSDomain), nil
+}
+
+// GenerateAuthCode records the call and calls GenerateAuthCodeFunc.
+func (m *DNSDomainAPI) GenerateAuthCode(ctx context.Context, domainname string) (string, error) {
+	m.record("GenerateAuthCode", domainname)
+	if m.GenerateAuthCodeFunc != nil {
+		return m.GenerateAuthCodeFunc(ctx, domainname)
+	}
+	return "", nil
+}
+
+// Register records the call and calls RegisterFunc.
+func (m *DNSDomainAPI) Register(ctx context.Context, params glesys.RegisterDNSDomainParams) (*glesys.DNSDomain, error) {
+	m.record("Register", params)
+	if m.RegisterFunc != nil {
+		return m.RegisterFunc(ctx, params)
+	}
+	return new(glesys.DNSDomain), nil
+}
+
+// Renew records the call and calls RenewFunc.
+func (m *DNSDomainAPI) Renew(ctx context.Context, params glesys.RenewDNSDomainParams) (*glesys.DNSDomain, error) {
+	m.record("Renew", params)
+	if m.RenewFunc != nil {
+		return m.RenewFunc(ctx, params)
+	}
+	return new(glesys.DNSDomain), nil
+}
+
+// SetAutoRenew records the call and calls SetAutoRenewFunc.
+func (m *DNSDomainAPI) SetAutoRenew(ctx context.Context, params glesys.SetAutoRenewParams) (*glesys.DNSDomain, error) {
+	m.record("SetAutoRenew", params)
+	if m.SetAutoRenewFunc != nil {
+		return m.SetAutoRenewFunc(ctx, params)
+	}
+	return new(glesys.DNSDomain), nil
+}
+
+// Transfer records the call and calls TransferFunc.
+func (m *DNSDomainAPI) Transfer(ctx context.Context, params glesys.RegisterDNSDomainParams) (*glesys.DNSDomain, error) {
+	m.record("Transfer", params)
+	if m.TransferFunc != nil {
+		return m.TransferFunc(ctx, params)
+	}
+	return new(glesys.DNSDomain), nil
+}
+
+// ListRecords records the call and calls ListRecordsFunc.
+func (m *DNSDomainAPI) ListRecords(ctx context.Context, domainname string) (*[]glesys.DNSDomainRecord, error) {
+	m.record("ListRecords", domainname)
+	if m.ListRecordsFunc != nil {
+		return m.ListRecordsFunc(ctx, domainname)
+	}
+	return new([]glesys.DNSDomainRecord), nil
+}
+
+// AddRecord records the call and calls AddRecordFunc.
+func (m *DNSDomainAPI) AddRecord(ctx context.Context, params glesys.AddRecordParams) (*glesys.DNSDomainRecord, error) {
+	m.record("AddRecord", params)
+	if m.AddRecordFunc != nil {
+		return m.AddRecordFunc(ctx, params)
+	}
+	return new(glesys.DNSDomainRecord), nil
+}
+
+// UpdateRecord records the call and calls UpdateRecordFunc.
+func (m *DNSDomainAPI) UpdateRecord(ctx context.Context, params glesys.UpdateRecordParams) (*glesys.DNSDomainRecord, error) {
+	m.record("UpdateRecord", params)
+	if m.UpdateRecordFunc != nil {
+		return m.UpdateRecordFunc(ctx, params)
+	}
+	return new(glesys.DNSDomainRecord), nil
+}
+
+// DeleteRecord records the call and calls DeleteRecordFunc.
+func (m *DNSDomainAPI) DeleteRecord(ctx context.Context, recordID int) error {
+	m.record("DeleteRecord", recordID)
+	if m.DeleteRecordFunc != nil {
+		return m.DeleteRecordFunc(ctx, recordID)
+	}
+	return nil
+}
+
+// ChangeNameservers records the call and calls ChangeNameserversFunc.
+func (m *DNSDomainAPI) ChangeNameservers(ctx context.Context, params glesys.ChangeNameserverParams) error {
+	m.record("ChangeNameservers", params)
+	if m.ChangeNameserversFunc != nil {
+		return m.ChangeNameserversFunc(ctx, params)
+	}
+	return nil
+}
+
+// DatabaseAPI is a programmable fake of glesys.DatabaseAPI.
+type DatabaseAPI struct {
+	Recorder
+
+	// CreateFunc is called by Create if set.
+	CreateFunc func(ctx context.Context, params glesys.CreateDatabaseParams) (*glesys.DatabaseDetails, error)
+	// DeleteFunc is called by Delete if set.
+	DeleteFunc func(ctx context.Context, databaseID string) error
+	// UpdateAllowlistFunc is called by UpdateAllowlist if set.
+	UpdateAllowlistFunc func(ctx context.Context, params glesys.UpdateAllowlistParams) (*glesys.DatabaseDetails, error)
+	// ListFunc is called by List if set.
+	ListFunc func(ctx context.Context) (*[]glesys.Database, error)
+	// DetailsFunc is called by Details if set.
+	DetailsFunc func(ctx context.Context, databaseID string) (*glesys.DatabaseDetails, error)
+	// ConnectionStringFunc is called by ConnectionString if set.
+	ConnectionStringFunc func(ctx context.Context, databaseID string) (*glesys.ConnectionDetails, error)
+	// ListPlansFunc is called by ListPlans if set.
+	ListPlansFunc func(ctx context.Context) (*[]glesys.DatabasePlan, error)
+	// EstimatedCostFunc is called by EstimatedCost if set.
+	EstimatedCostFunc func(ctx context.Context, params glesys.EstimatedCostParams) (*glesys.Billing, error)
+}
+
+var _ glesys.DatabaseAPI = (*DatabaseAPI)(nil)
+
+// Create records the call and calls CreateFunc.
+func (m *DatabaseAPI) Create(ctx context.Context, params glesys.CreateDatabaseParams) (*glesys.DatabaseDetails, error) {
+	m.record("Create", params)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, params)
+	}
+	return new(glesys.DatabaseDetails), nil
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *DatabaseAPI) Delete(ctx context.Context, databaseID string) error {
+	m.record("Delete", databaseID)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, databaseID)
+	}
+	return nil
+}
+
+// UpdateAllowlist records the call and calls UpdateAllowlistFunc.
+func (m *DatabaseAPI) UpdateAllowlist(ctx context.Context, params glesys.UpdateAllowlistParams) (*glesys.DatabaseDetails, error) {
+	m.record("UpdateAllowlist", params)
+	if m.UpdateAllowlistFunc != nil {
+		return m.UpdateAllowlistFunc(ctx, params)
+	}
+	return new(glesys.DatabaseDetails), nil
+}
+
+// List records the call and calls ListFunc.
+func (m *DatabaseAPI) List(ctx context.Context) (*[]glesys.Database, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return new([]glesys.Database), nil
+}
+
+// Details records the call and calls DetailsFunc.
+func (m *DatabaseAPI) Details(ctx context.Context, databaseID string) (*glesys.DatabaseDetails, error) {
+	m.record("Details", databaseID)
+	if m.DetailsFunc != nil {
+		return m.DetailsFunc(ctx, databaseID)
+	}
+	return new(glesys.DatabaseDetails), nil
+}
+
+// ConnectionString records the call and calls ConnectionStringFunc.
+func (m *DatabaseAPI) ConnectionString(ctx context.Context, databaseID string) (*glesys.ConnectionDetails, error) {
+	m.record("ConnectionString", databaseID)
+	if m.ConnectionStringFunc != nil {
+		return m.ConnectionStringFunc(ctx, databaseID)
+	}
+	return new(glesys.ConnectionDetails), nil
+}
+
+// ListPlans records the call and calls ListPlansFunc.
+func (m *DatabaseAPI) ListPlans(ctx context.Context) (*[]glesys.DatabasePlan, error) {
+	m.record("ListPlans")
+	if m.ListPlansFunc != nil {
+		return m.ListPlansFunc(ctx)
+	}
+	return new([]glesys.DatabasePlan), nil
+}
+
+// EstimatedCost records the call and calls EstimatedCostFunc.
+func (m *DatabaseAPI) EstimatedCost(ctx context.Context, params glesys.EstimatedCostParams) (*glesys.Billing, error) {
+	m.record("EstimatedCost", params)
+	if m.EstimatedCostFunc != nil {
+		return m.EstimatedCostFunc(ctx, params)
+	}
+	return new(glesys.Billing), nil
+}
+
+// EmailDomainAPI is a programmable fake of glesys.EmailDomainAPI.
+type EmailDomainAPI struct {
+	Recorder
+
+	// OverviewFunc is called by Overview if set.
+	OverviewFunc func(ctx context.Context, params glesys.OverviewParams) (*glesys.EmailOverview, error)
+	// ListFunc is called by List if set.
+	ListFunc func(ctx context.Context, domain string, params glesys.ListEmailsParams) (*glesys.EmailList, error)
+	// EditAccountFunc is called by EditAccount if set.
+	EditAccountFunc func(ctx context.Context, emailAccount string, params glesys.EditAccountParams) (*glesys.EmailAccount, error)
+	// DeleteFunc is called by Delete if set.
+	DeleteFunc func(ctx context.Context, email string) error
+	// CreateAccountFunc is called by CreateAccount if set.
+	CreateAccountFunc func(ctx context.Context, params glesys.CreateAccountParams) (*glesys.EmailAccount, error)
+	// QuotaFunc is called by Quota if set.
+	QuotaFunc func(ctx context.Context, emailaccount string) (*glesys.EmailQuota, error)
+	// CreateAliasFunc is called by CreateAlias if set.
+	CreateAliasFunc func(ctx context.Context, params glesys.EmailAliasParams) (*glesys.EmailAlias, error)
+	// EditAliasFunc is called by EditAlias if set.
+	EditAliasFunc func(ctx context.Context, params glesys.EmailAliasParams) (*glesys.EmailAlias, error)
+	// CostsFunc is called by Costs if set.
+	CostsFunc func(ctx context.Context) (*glesys.EmailCosts, error)
+	// ResetPasswordFunc is called by ResetPassword if set.
+	ResetPasswordFunc func(ctx context.Context, emailaccount string) (string, error)
+}
+
+var _ glesys.EmailDomainAPI = (*EmailDomainAPI)(nil)
+
+// Overview records the call and calls OverviewFunc.
+func (m *EmailDomainAPI) Overview(ctx context.Context, params glesys.OverviewParams) (*glesys.EmailOverview, error) {
+	m.record("Overview", params)
+	if m.OverviewFunc != nil {
+		return m.OverviewFunc(ctx, params)
+	}
+	return new(glesys.EmailOverview), nil
+}
+
+// List records the call and calls ListFunc.
+func (m *EmailDomainAPI) List(ctx context.Context, domain string, params glesys.ListEmailsParams) (*glesys.EmailList, error) {
+	m.record("List", domain, params)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, domain, params)
+	}
+	return new(glesys.EmailList), nil
+}
+
+// EditAccount records the call and calls EditAccountFunc.
+func (m *EmailDomainAPI) EditAccount(ctx context.Context, emailAccount string, params glesys.EditAccountParams) (*glesys.EmailAccount, error) {
+	m.record("EditAccount", emailAccount, params)
+	if m.EditAccountFunc != nil {
+		return m.EditAccountFunc(ctx, emailAccount, params)
+	}
+	return new(glesys.EmailAccount), nil
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *EmailDomainAPI) Delete(ctx context.Context, email string) error {
+	m.record("Delete", email)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, email)
+	}
+	return nil
+}
+
+// CreateAccount records the call and calls CreateAccountFunc.
+func (m *EmailDomainAPI) CreateAccount(ctx context.Context, params glesys.CreateAccountParams) (*glesys.EmailAccount, error) {
+	m.record("CreateAccount", params)
+	if m.CreateAccountFunc != nil {
+		return m.CreateAccountFunc(ctx, params)
+	}
+	return new(glesys.EmailAccount), nil
+}
+
+// Quota records the call and calls QuotaFunc.
+func (m *EmailDomainAPI) Quota(ctx context.Context, emailaccount string) (*glesys.EmailQuota, error) {
+	m.record("Quota", emailaccount)
+	if m.QuotaFunc != nil {
+		return m.QuotaFunc(ctx, emailaccount)
+	}
+	return new(glesys.EmailQuota), nil
+}
+
+// CreateAlias records the call and calls CreateAliasFunc.
+func (m *EmailDomainAPI) CreateAlias(ctx context.Context, params glesys.EmailAliasParams) (*glesys.EmailAlias, error) {
+	m.record("CreateAlias", params)
+	if m.CreateAliasFunc != nil {
+		return m.CreateAliasFunc(ctx, params)
+	}
+	return new(glesys.EmailAlias), nil
+}
+
+// EditAlias records the call and calls EditAliasFunc.
+func (m *EmailDomainAPI) EditAlias(ctx context.Context, params glesys.EmailAliasParams) (*glesys.EmailAlias, error) {
+	m.record("EditAlias", params)
+	if m.EditAliasFunc != nil {
+		return m.EditAliasFunc(ctx, params)
+	}
+	return new(glesys.EmailAlias), nil
+}
+
+// Costs records the call and calls CostsFunc.
+func (m *EmailDomainAPI) Costs(ctx context.Context) (*glesys.EmailCosts, error) {
+	m.record("Costs")
+	if m.CostsFunc != nil {
+		return m.CostsFunc(ctx)
+	}
+	return new(glesys.EmailCosts), nil
+}
+
+// ResetPassword records the call and calls ResetPasswordFunc.
+func (m *EmailDomainAPI) ResetPassword(ctx context.Context, emailaccount string) (string, error) {
+	m.record("ResetPassword", emailaccount)
+	if m.ResetPasswordFunc != nil {
+		return m.ResetPasswordFunc(ctx, emailaccount)
+	}
+	return "", nil
+}
+
+// IPAPI is a programmable fake of glesys.IPAPI.
+type IPAPI struct {
+	Recorder
+
+	// AvailableFunc is called by Available if set.
+	AvailableFunc func(ctx context.Context, params glesys.AvailableIPsParams) (*[]glesys.IP, error)
+	// DetailsFunc is called by Details if set.
+	DetailsFunc func(ctx context.Context, ipAddress string) (*glesys.IP, error)
+	// ReleaseFunc is called by Release if set.
+	ReleaseFunc func(ctx context.Context, ipAddress string) error
+	// ReserveFunc is called by Reserve if set.
+	ReserveFunc func(ctx context.Context, ipAddress string) (*glesys.IP, error)
+	// ReservedFunc is called by Reserved if set.
+	ReservedFunc func(ctx context.Context, params glesys.ReservedIPsParams) (*[]glesys.IP, error)
+	// SetPTRFunc is called by SetPTR if set.
+	SetPTRFunc func(ctx context.Context, ipAddress string, ptrdata string) (*glesys.IP, error)
+	// ResetPTRFunc is called by ResetPTR if set.
+	ResetPTRFunc func(ctx context.Context, ipAddress string) (*glesys.IP, error)
+}
+
+var _ glesys.IPAPI = (*IPAPI)(nil)
+
+// Available records the call and calls AvailableFunc.
+func (m *IPAPI) Available(ctx context.Context, params glesys.AvailableIPsParams) (*[]glesys.IP, error) {
+	m.record("Available", params)
+	if m.AvailableFunc != nil {
+		return m.AvailableFunc(ctx, params)
+	}
+	return new([]glesys.IP), nil
+}
+
+// Details records the call and calls DetailsFunc.
+func (m *IPAPI) Details(ctx context.Context, ipAddress string) (*glesys.IP, error) {
+	m.record("Details", ipAddress)
+	if m.DetailsFunc != nil {
+		return m.DetailsFunc(ctx, ipAddress)
+	}
+	return new(glesys.IP), nil
+}
+
+// Release records the call and calls ReleaseFunc.
+func (m *IPAPI) Release(ctx context.Context, ipAddress string) error {
+	m.record("Release", ipAddress)
+	if m.ReleaseFunc != nil {
+		return m.ReleaseFunc(ctx, ipAddress)
+	}
+	return nil
+}
+
+// Reserve records the call and calls ReserveFunc.
+func (m *IPAPI) Reserve(ctx context.Context, ipAddress string) (*glesys.IP, error) {
+	m.record("Reserve", ipAddress)
+	if m.ReserveFunc != nil {
+		return m.ReserveFunc(ctx, ipAddress)
+	}
+	return new(glesys.IP), nil
+}
+
+// Reserved records the call and calls ReservedFunc.
+func (m *IPAPI) Reserved(ctx context.Context, params glesys.ReservedIPsParams) (*[]glesys.IP, error) {
+	m.record("Reserved", params)
+	if m.ReservedFunc != nil {
+		return m.ReservedFunc(ctx, params)
+	}
+	return new([]glesys.IP), nil
+}
+
+// SetPTR records the call and calls SetPTRFunc.
+func (m *IPAPI) SetPTR(ctx context.Context, ipAddress string, ptrdata string) (*glesys.IP, error) {
+	m.record("SetPTR", ipAddress, ptrdata)
+	if m.SetPTRFunc != nil {
+		return m.SetPTRFunc(ctx, ipAddress, ptrdata)
+	}
+	return new(glesys.IP), nil
+}
+
+// ResetPTR records the call and calls ResetPTRFunc.
+func (m *IPAPI) ResetPTR(ctx context.Context, ipAddress string) (*glesys.IP, error) {
+	m.record("ResetPTR", ipAddress)
+	if m.ResetPTRFunc != nil {
+		return m.ResetPTRFunc(ctx, ipAddress)
+	}
+	return new(glesys.IP), nil
+}
+
+// LoadBalancerAPI is a programmable fake of glesys.LoadBalancerAPI.
+type LoadBalancerAPI struct {
+	Recorder
+
+	// CreateFunc is called by Create if set.
+	CreateFunc func(ctx context.Context, params glesys.CreateLoadBalancerParams) (*glesys.LoadBalancerDetails, error)
+	// DestroyFunc is called by Destroy if set.
+	DestroyFunc func(ctx context.Context, loadbalancerID string) error
+	// DetailsFunc is called by Details if set.
+	DetailsFunc func(ctx context.Context, loadbalancerID string) (*glesys.LoadBalancerDetails, error)
+	// EditFunc is called by Edit if set.
+	EditFunc func(ctx context.Context, loadbalancerID string, params glesys.EditLoadBalancerParams) (*glesys.LoadBalancerDetails, error)
+	// ListFunc is called by List if set.
+	ListFunc func(ctx context.Context) (*[]glesys.LoadBalancer, error)
+	// AddBackendFunc is called by AddBackend if set.
+	AddBackendFunc func(ctx context.Context, loadbalancerID string, params glesys.AddBackendParams) (*glesys.LoadBalancerDetails, error)
+	// EditBackendFunc is called by EditBackend if set.
+	EditBackendFunc func(ctx context.Context, loadbalancerID string, params glesys.EditBackendParams) (*glesys.LoadBalancerDetails, error)
+	// RemoveBackendFunc is called by RemoveBackend if set.
+	RemoveBackendFunc func(ctx context.Context, loadbalancerID string, params glesys.RemoveBackendParams) error
+	// AddFrontendFunc is called by AddFrontend if set.
+	AddFrontendFunc func(ctx context.Context, loadbalancerID string, params glesys.AddFrontendParams) (*glesys.LoadBalancerDetails, error)
+	// EditFrontendFunc is called by EditFrontend if set.
+	EditFrontendFunc func(ctx context.Context, loadbalancerID string, params glesys.EditFrontendParams) (*glesys.LoadBalancerDetails, error)
+	// RemoveFrontendFunc is called by RemoveFrontend if set.
+	RemoveFrontendFunc func(ctx context.Context, loadbalancerID string, params glesys.RemoveFrontendParams) error
+	// AddCertificateFunc is called by AddCertificate if set.
+	AddCertificateFunc func(ctx context.Context, loadbalancerID string, params glesys.AddCertificateParams) error
+	// ListCertificatesFunc is called by ListCertificates if set.
+	ListCertificatesFunc func(ctx context.Context, loadbalancerID string) (*[]string, error)
+	// RemoveCertificateFunc is called by RemoveCertificate if set.
+	RemoveCertificateFunc func(ctx context.Context, loadbalancerID string, params string) error
+	// AddTargetFunc is called by AddTarget if set.
+	AddTargetFunc func(ctx context.Context, loadbalancerID string, params glesys.AddTargetParams) (*glesys.LoadBalancerDetails, error)
+	// EditTargetFunc is called by EditTarget if set.
+	EditTargetFunc func(ctx context.Context, loadbalancerID string, params glesys.EditTargetParams) (*glesys.LoadBalancerDetails, error)
+	// EnableTargetFunc is called by EnableTarget if set.
+	EnableTargetFunc func(ctx context.Context, loadbalancerID string, params glesys.ToggleTargetParams) (*glesys.LoadBalancerDetails, error)
+	// DisableTargetFunc is called by DisableTarget if set.
+	DisableTargetFunc func(ctx context.Context, loadbalancerID string, params glesys.ToggleTargetParams) (*glesys.LoadBalancerDetails, error)
+	// RemoveTargetFunc is called by RemoveTarget if set.
+	RemoveTargetFunc func(ctx context.Context, loadbalancerID string, params glesys.RemoveTargetParams) error
+	// AddToBlocklistFunc is called by AddToBlocklist if set.
+	AddToBlocklistFunc func(ctx context.Context, loadbalancerID string, params glesys.BlocklistParams) (*glesys.LoadBalancerDetails, error)
+	// RemoveFromBlocklistFunc is called by RemoveFromBlocklist if set.
+	RemoveFromBlocklistFunc func(ctx context.Context, loadbalancerID string, params glesys.BlocklistParams) (*glesys.LoadBalancerDetails, error)
+}
+
+var _ glesys.LoadBalancerAPI = (*LoadBalancerAPI)(nil)
+
+// Create records the call and calls CreateFunc.
+func (m *LoadBalancerAPI) Create(ctx context.Context, params glesys.CreateLoadBalancerParams) (*glesys.LoadBalancerDetails, error) {
+	m.record("Create", params)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, params)
+	}
+	return new(glesys.LoadBalancerDetails), nil
+}
+
+// Destroy records the call and calls DestroyFunc.
+func (m *LoadBalancerAPI) Destroy(ctx context.Context, loadbalancerID string) error {
+	m.record("Destroy", loadbalancerID)
+	if m.DestroyFunc != nil {
+		return m.DestroyFunc(ctx, loadbalancerID)
+	}
+	return nil
+}
+
+// Details records the call and calls DetailsFunc.
+func (m *LoadBalancerAPI) Details(ctx context.Context, loadbalancerID string) (*glesys.LoadBalancerDetails, error) {
+	m.record("Details", loadbalancerID)
+	if m.DetailsFunc != nil {
+		return m.DetailsFunc(ctx, loadbalancerID)
+	}
+	return new(glesys.LoadBalancerDetails), nil
+}
+
+// Edit records the call and calls EditFunc.
+func (m *LoadBalancerAPI) Edit(ctx context.Context, loadbalancerID string, params glesys.EditLoadBalancerParams) (*glesys.LoadBalancerDetails, error) {
+	m.record("Edit", loadbalancerID, params)
+	if m.EditFunc != nil {
+		return m.EditFunc(ctx, loadbalancerID, params)
+	}
+	return new(glesys.LoadBalancerDetails), nil
+}
+
+// List records the call and calls ListFunc.
+func (m *LoadBalancerAPI) List(ctx context.Context) (*[]glesys.LoadBalancer, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return new([]glesys.LoadBalancer), nil
+}
+
+// AddBackend records the call and calls AddBackendFunc.
+func (m *LoadBalancerAPI) AddBackend(ctx context.Context, loadbalancerID string, params glesys.AddBackendParams) (*glesys.LoadBalancerDetails, error) {
+	m.record("AddBackend", loadbalancerID, params)
+	if m.AddBackendFunc != nil {
+		return m.AddBackendFunc(ctx, loadbalancerID, params)
+	}
+	return new(glesys.LoadBalancerDetails), nil
+}
+
+// EditBackend records the call and calls EditBackendFunc.
+func (m *LoadBalancerAPI) EditBackend(ctx context.Context, loadbalancerID string, params glesys.EditBackendParams) (*glesys.LoadBalancerDetails, error) {
+	m.record("EditBackend", loadbalancerID, params)
+	if m.EditBackendFunc != nil {
+		return m.EditBackendFunc(ctx, loadbalancerID, params)
+	}
+	return new(glesys.LoadBalancerDetails), nil
+}
+
+// RemoveBackend records the call and calls RemoveBackendFunc.
+func (m *LoadBalancerAPI) RemoveBackend(ctx context.Context, loadbalancerID string, params glesys.RemoveBackendParams) error {
+	m.record("RemoveBackend", loadbalancerID, params)
+	if m.RemoveBackendFunc != nil {
+		return m.RemoveBackendFunc(ctx, loadbalancerID, params)
+	}
+	return nil
+}
+
+// AddFrontend records the call and calls AddFrontendFunc.
+func (m *LoadBalancerAPI) AddFrontend(ctx context.Context, loadbalancerID string, params glesys.AddFrontendParams) (*glesys.LoadBalancerDetails, error) {
+	m.record("AddFrontend", loadbalancerID, params)
+	if m.AddFrontendFunc != nil {
+		return m.AddFrontendFunc(ctx, loadbalancerID, params)
+	}
+	return new(glesys.LoadBalancerDetails), nil
+}
+
+// EditFrontend records the call and calls EditFrontendFunc.
+func (m *LoadBalancerAPI) EditFrontend(ctx context.Context, loadbalancerID string, params glesys.EditFrontendParams) (*glesys.LoadBalancerDetails, error) {
+	m.record("EditFrontend", loadbalancerID, params)
+	if m.EditFrontendFunc != nil {
+		return m.EditFrontendFunc(ctx, loadbalancerID, params)
+	}
+	return new(glesys.LoadBalancerDetails), nil
+}
+
+// RemoveFrontend records the call and calls RemoveFrontendFunc.
+func (m *LoadBalancerAPI) RemoveFrontend(ctx context.Context, loadbalancerID string, params glesys.RemoveFrontendParams) error {
+	m.record("RemoveFrontend", loadbalancerID, params)
+	if m.RemoveFrontendFunc != nil {
+		return m.RemoveFrontendFunc(ctx, loadbalancerID, params)
+	}
+	return nil
+}
+
+// AddCertificate records the call and calls AddCertificateFunc.
+func (m *LoadBalancerAPI) AddCertificate(ctx context.Context, loadbalancerID string, params glesys.AddCertificateParams) error {
+	m.record("AddCertificate", loadbalancerID, params)
+	if m.AddCertificateFunc != nil {
+		return m.AddCertificateFunc(ctx, loadbalancerID, params)
+	}
+	return nil
+}
+
+// ListCertificates records the call and calls ListCertificatesFunc.
+func (m *LoadBalancerAPI) ListCertificates(ctx context.Context, loadbalancerID string) (*[]string, error) {
+	m.record("ListCertificates", loadbalancerID)
+	if m.ListCertificatesFunc != nil {
+		return m.ListCertificatesFunc(ctx, loadbalancerID)
+	}
+	return new([]string), nil
+}
+
+// RemoveCertificate records the call and calls RemoveCertificateFunc.
+func (m *LoadBalancerAPI) RemoveCertificate(ctx context.Context, loadbalancerID string, params string) error {
+	m.record("RemoveCertificate", loadbalancerID, params)
+	if m.RemoveCertificateFunc != nil {
+		return m.RemoveCertificateFunc(ctx, loadbalancerID, params)
+	}
+	return nil
+}
+
+// AddTarget records the call and calls AddTargetFunc.
+func (m *LoadBalancerAPI) AddTarget(ctx context.Context, loadbalancerID string, params glesys.AddTargetParams) (*glesys.LoadBalancerDetails, error) {
+	m.record("AddTarget", loadbalancerID, params)
+	if m.AddTargetFunc != nil {
+		return m.AddTargetFunc(ctx, loadbalancerID, params)
+	}
+	return new(glesys.LoadBalancerDetails), nil
+}
+
+// EditTarget records the call and calls EditTargetFunc.
+func (m *LoadBalancerAPI) EditTarget(ctx context.Context, loadbalancerID string, params glesys.EditTargetParams) (*glesys.LoadBalancerDetails, error) {
+	m.record("EditTarget", loadbalancerID, params)
+	if m.EditTargetFunc != nil {
+		return m.EditTargetFunc(ctx, loadbalancerID, params)
+	}
+	return new(glesys.LoadBalancerDetails), nil
+}
+
+// EnableTarget records the call and calls EnableTargetFunc.
+func (m *LoadBalancerAPI) EnableTarget(ctx context.Context, loadbalancerID string, params glesys.ToggleTargetParams) (*glesys.LoadBalancerDetails, error) {
+	m.record("EnableTarget", loadbalancerID, params)
+	if m.EnableTargetFunc != nil {
+		return m.EnableTargetFunc(ctx, loadbalancerID, params)
+	}
+	return new(glesys.LoadBalancerDetails), nil
+}
+
+// DisableTarget records the call and calls DisableTargetFunc.
+func (m *LoadBalancerAPI) DisableTarget(ctx context.Context, loadbalancerID string, params glesys.ToggleTargetParams) (*glesys.LoadBalancerDetails, error) {
+	m.record("DisableTarget", loadbalancerID, params)
+	if m.DisableTargetFunc != nil {
+		return m.DisableTargetFunc(ctx, loadbalancerID, params)
+	}
+	return new(glesys.LoadBalancerDetails), nil
+}
+
+// RemoveTarget records the call and calls RemoveTargetFunc.
+func (m *LoadBalancerAPI) RemoveTarget(ctx context.Context, loadbalancerID string, params glesys.RemoveTargetParams) error {
+	m.record("RemoveTarget", loadbalancerID, params)
+	if m.RemoveTargetFunc != nil {
+		return m.RemoveTargetFunc(ctx, loadbalancerID, params)
+	}
+	return nil
+}
+
+// AddToBlocklist records the call and calls AddToBlocklistFunc.
+func (m *LoadBalancerAPI) AddToBlocklist(ctx context.Context, loadbalancerID string, params glesys.BlocklistParams) (*glesys.LoadBalancerDetails, error) {
+	m.record("AddToBlocklist", loadbalancerID, params)
+	if m.AddToBlocklistFunc != nil {
+		return m.AddToBlocklistFunc(ctx, loadbalancerID, params)
+	}
+	return new(glesys.LoadBalancerDetails), nil
+}
+
+// RemoveFromBlocklist records the call and calls RemoveFromBlocklistFunc.
+func (m *LoadBalancerAPI) RemoveFromBlocklist(ctx context.Context, loadbalancerID string, params glesys.BlocklistParams) (*glesys.LoadBalancerDetails, error) {
+	m.record("RemoveFromBlocklist", loadbalancerID, params)
+	if m.RemoveFromBlocklistFunc != nil {
+		return m.RemoveFromBlocklistFunc(ctx, loadbalancerID, params)
+	}
+	return new(glesys.LoadBalancerDetails), nil
+}
+
+// NetworkAPI is a programmable fake of glesys.NetworkAPI.
+type NetworkAPI struct {
+	Recorder
+
+	// CreateFunc is called by Create if set.
+	CreateFunc func(ctx context.Context, params glesys.CreateNetworkParams) (*glesys.Network, error)
+	// DetailsFunc is called by Details if set.
+	DetailsFunc func(ctx context.Context, networkID string) (*glesys.Network, error)
+	// DestroyFunc is called by Destroy if set.
+	DestroyFunc func(ctx context.Context, networkID string) error
+	// EditFunc is called by Edit if set.
+	EditFunc func(ctx context.Context, networkID string, params glesys.EditNetworkParams) (*glesys.Network, error)
+	// ListFunc is called by List if set.
+	ListFunc func(ctx context.Context) (*[]glesys.Network, error)
+}
+
+var _ glesys.NetworkAPI = (*NetworkAPI)(nil)
+
+// Create records the call and calls CreateFunc.
+func (m *NetworkAPI) Create(ctx context.Context, params glesys.CreateNetworkParams) (*glesys.Network, error) {
+	m.record("Create", params)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, params)
+	}
+	return new(glesys.Network), nil
+}
+
+// Details records the call and calls DetailsFunc.
+func (m *NetworkAPI) Details(ctx context.Context, networkID string) (*glesys.Network, error) {
+	m.record("Details", networkID)
+	if m.DetailsFunc != nil {
+		return m.DetailsFunc(ctx, networkID)
+	}
+	return new(glesys.Network), nil
+}
+
+// Destroy records the call and calls DestroyFunc.
+func (m *NetworkAPI) Destroy(ctx context.Context, networkID string) error {
+	m.record("Destroy", networkID)
+	if m.DestroyFunc != nil {
+		return m.DestroyFunc(ctx, networkID)
+	}
+	return nil
+}
+
+// Edit records the call and calls EditFunc.
+func (m *NetworkAPI) Edit(ctx context.Context, networkID string, params glesys.EditNetworkParams) (*glesys.Network, error) {
+	m.record("Edit", networkID, params)
+	if m.EditFunc != nil {
+		return m.EditFunc(ctx, networkID, params)
+	}
+	return new(glesys.Network), nil
+}
+
+// List records the call and calls ListFunc.
+func (m *NetworkAPI) List(ctx context.Context) (*[]glesys.Network, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return new([]glesys.Network), nil
+}
+
+// NetworkAdapterAPI is a programmable fake of glesys.NetworkAdapterAPI.
+type NetworkAdapterAPI struct {
+	Recorder
+
+	// CreateFunc is called by Create if set.
+	CreateFunc func(ctx context.Context, params glesys.CreateNetworkAdapterParams) (*glesys.NetworkAdapter, error)
+	// DetailsFunc is called by Details if set.
+	DetailsFunc func(ctx context.Context, networkAdapterID string) (*glesys.NetworkAdapter, error)
+	// DestroyFunc is called by Destroy if set.
+	DestroyFunc func(ctx context.Context, networkAdapterID string) error
+	// EditFunc is called by Edit if set.
+	EditFunc func(ctx context.Context, networkAdapterID string, params glesys.EditNetworkAdapterParams) (*glesys.NetworkAdapter, error)
+}
+
+var _ glesys.NetworkAdapterAPI = (*NetworkAdapterAPI)(nil)
+
+// Create records the call and calls CreateFunc.
+func (m *NetworkAdapterAPI) Create(ctx context.Context, params glesys.CreateNetworkAdapterParams) (*glesys.NetworkAdapter, error) {
+	m.record("Create", params)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, params)
+	}
+	return new(glesys.NetworkAdapter), nil
+}
+
+// Details records the call and calls DetailsFunc.
+func (m *NetworkAdapterAPI) Details(ctx context.Context, networkAdapterID string) (*glesys.NetworkAdapter, error) {
+	m.record("Details", networkAdapterID)
+	if m.DetailsFunc != nil {
+		return m.DetailsFunc(ctx, networkAdapterID)
+	}
+	return new(glesys.NetworkAdapter), nil
+}
+
+// Destroy records the call and calls DestroyFunc.
+func (m *NetworkAdapterAPI) Destroy(ctx context.Context, networkAdapterID string) error {
+	m.record("Destroy", networkAdapterID)
+	if m.DestroyFunc != nil {
+		return m.DestroyFunc(ctx, networkAdapterID)
+	}
+	return nil
+}
+
+// Edit records the call and calls EditFunc.
+func (m *NetworkAdapterAPI) Edit(ctx context.Context, networkAdapterID string, params glesys.EditNetworkAdapterParams) (*glesys.NetworkAdapter, error) {
+	m.record("Edit", networkAdapterID, params)
+	if m.EditFunc != nil {
+		return m.EditFunc(ctx, networkAdapterID, params)
+	}
+	return new(glesys.NetworkAdapter), nil
+}
+
+// NetworkCircuitAPI is a programmable fake of glesys.NetworkCircuitAPI.
+type NetworkCircuitAPI struct {
+	Recorder
+
+	// DetailsFunc is called by Details if set.
+	DetailsFunc func(ctx context.Context, circuitID string) (*glesys.NetworkCircuit, error)
+	// ListFunc is called by List if set.
+	ListFunc func(ctx context.Context) (*[]glesys.NetworkCircuit, error)
+}
+
+var _ glesys.NetworkCircuitAPI = (*NetworkCircuitAPI)(nil)
+
+// Details records the call and calls DetailsFunc.
+func (m *NetworkCircuitAPI) Details(ctx context.Context, circuitID string) (*glesys.NetworkCircuit, error) {
+	m.record("Details", circuitID)
+	if m.DetailsFunc != nil {
+		return m.DetailsFunc(ctx, circuitID)
+	}
+	return new(glesys.NetworkCircuit), nil
+}
+
+// List records the call and calls ListFunc.
+func (m *NetworkCircuitAPI) List(ctx context.Context) (*[]glesys.NetworkCircuit, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return new([]glesys.NetworkCircuit), nil
+}
+
+// ObjectStorageAPI is a programmable fake of glesys.ObjectStorageAPI.
+type ObjectStorageAPI struct {
+	Recorder
+
+	// CreateInstanceFunc is called by CreateInstance if set.
+	CreateInstanceFunc func(ctx context.Context, params glesys.CreateObjectStorageInstanceParams) (*glesys.ObjectStorageInstance, error)
+	// InstanceDetailsFunc is called by InstanceDetails if set.
+	InstanceDetailsFunc func(ctx context.Context, instanceID string) (*glesys.ObjectStorageInstance, error)
+	// DeleteInstanceFunc is called by DeleteInstance if set.
+	DeleteInstanceFunc func(ctx context.Context, instanceID string) error
+	// EditInstanceFunc is called by EditInstance if set.
+	EditInstanceFunc func(ctx context.Context, params glesys.EditObjectStorageInstanceParams) (*glesys.ObjectStorageInstance, error)
+	// ListInstancesFunc is called by ListInstances if set.
+	ListInstancesFunc func(ctx context.Context) (*[]glesys.ObjectStorageInstance, error)
+	// CreateCredentialFunc is called by CreateCredential if set.
+	CreateCredentialFunc func(ctx context.Context, params glesys.CreateObjectStorageCredentialParams) (*glesys.ObjectStorageCredential, error)
+	// DeleteCredentialFunc is called by DeleteCredential if set.
+	DeleteCredentialFunc func(ctx context.Context, params glesys.DeleteObjectStorageCredentialParams) error
+}
+
+var _ glesys.ObjectStorageAPI = (*ObjectStorageAPI)(nil)
+
+// CreateInstance records the call and calls CreateInstanceFunc.
+func (m *ObjectStorageAPI) CreateInstance(ctx context.Context, params glesys.CreateObjectStorageInstanceParams) (*glesys.ObjectStorageInstance, error) {
+	m.record("CreateInstance", params)
+	if m.CreateInstanceFunc != nil {
+		return m.CreateInstanceFunc(ctx, params)
+	}
+	return new(glesys.ObjectStorageInstance), nil
+}
+
+// InstanceDetails records the call and calls InstanceDetailsFunc.
+func (m *ObjectStorageAPI) InstanceDetails(ctx context.Context, instanceID string) (*glesys.ObjectStorageInstance, error) {
+	m.record("InstanceDetails", instanceID)
+	if m.InstanceDetailsFunc != nil {
+		return m.InstanceDetailsFunc(ctx, instanceID)
+	}
+	return new(glesys.ObjectStorageInstance), nil
+}
+
+// DeleteInstance records the call and calls DeleteInstanceFunc.
+func (m *ObjectStorageAPI) DeleteInstance(ctx context.Context, instanceID string) error {
+	m.record("DeleteInstance", instanceID)
+	if m.DeleteInstanceFunc != nil {
+		return m.DeleteInstanceFunc(ctx, instanceID)
+	}
+	return nil
+}
+
+// EditInstance records the call and calls EditInstanceFunc.
+func (m *ObjectStorageAPI) EditInstance(ctx context.Context, params glesys.EditObjectStorageInstanceParams) (*glesys.ObjectStorageInstance, error) {
+	m.record("EditInstance", params)
+	if m.EditInstanceFunc != nil {
+		return m.EditInstanceFunc(ctx, params)
+	}
+	return new(glesys.ObjectStorageInstance), nil
+}
+
+// ListInstances records the call and calls ListInstancesFunc.
+func (m *ObjectStorageAPI) ListInstances(ctx context.Context) (*[]glesys.ObjectStorageInstance, error) {
+	m.record("ListInstances")
+	if m.ListInstancesFunc != nil {
+		return m.ListInstancesFunc(ctx)
+	}
+	return new([]glesys.ObjectStorageInstance), nil
+}
+
+// CreateCredential records the call and calls CreateCredentialFunc.
+func (m *ObjectStorageAPI) CreateCredential(ctx context.Context, params glesys.CreateObjectStorageCredentialParams) (*glesys.ObjectStorageCredential, error) {
+	m.record("CreateCredential", params)
+	if m.CreateCredentialFunc != nil {
+		return m.CreateCredentialFunc(ctx, params)
+	}
+	return new(glesys.ObjectStorageCredential), nil
+}
+
+// DeleteCredential records the call and calls DeleteCredentialFunc.
+func (m *ObjectStorageAPI) DeleteCredential(ctx context.Context, params glesys.DeleteObjectStorageCredentialParams) error {
+	m.record("DeleteCredential", params)
+	if m.DeleteCredentialFunc != nil {
+		return m.DeleteCredentialFunc(ctx, params)
+	}
+	return nil
+}
+
+// PrivateNetworkAPI is a programmable fake of glesys.PrivateNetworkAPI.
+type PrivateNetworkAPI struct {
+	Recorder
+
+	// CreateFunc is called by Create if set.
+	CreateFunc func(ctx context.Context, name string) (*glesys.PrivateNetwork, error)
+	// DetailsFunc is called by Details if set.
+	DetailsFunc func(ctx context.Context, privateNetworkID string) (*glesys.PrivateNetwork, error)
+	// ListFunc is called by List if set.
+	ListFunc func(ctx context.Context) (*[]glesys.PrivateNetwork, error)
+	// DestroyFunc is called by Destroy if set.
+	DestroyFunc func(ctx context.Context, privateNetworkID string) error
+	// EditFunc is called by Edit if set.
+	EditFunc func(ctx context.Context, params glesys.EditPrivateNetworkParams) (*glesys.PrivateNetwork, error)
+	// EstimatedCostFunc is called by EstimatedCost if set.
+	EstimatedCostFunc func(ctx context.Context, privateNetworkID string) (*glesys.PrivateNetworkBilling, error)
+	// CreateSegmentFunc is called by CreateSegment if set.
+	CreateSegmentFunc func(ctx context.Context, params glesys.CreatePrivateNetworkSegmentParams) (*glesys.PrivateNetworkSegment, error)
+	// EditSegmentFunc is called by EditSegment if set.
+	EditSegmentFunc func(ctx context.Context, params glesys.EditPrivateNetworkSegmentParams) (*glesys.PrivateNetworkSegment, error)
+	// ListSegmentsFunc is called by ListSegments if set.
+	ListSegmentsFunc func(ctx context.Context, privatenetworkid string) (*[]glesys.PrivateNetworkSegment, error)
+	// DestroySegmentFunc is called by DestroySegment if set.
+	DestroySegmentFunc func(ctx context.Context, id string) error
+}
+
+var _ glesys.PrivateNetworkAPI = (*PrivateNetworkAPI)(nil)
+
+// Create records the call and calls CreateFunc.
+func (m *PrivateNetworkAPI) Create(ctx context.Context, name string) (*glesys.PrivateNetwork, error) {
+	m.record("Create", name)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, name)
+	}
+	return new(glesys.PrivateNetwork), nil
+}
+
+// Details records the call and calls DetailsFunc.
+func (m *PrivateNetworkAPI) Details(ctx context.Context, privateNetworkID string) (*glesys.PrivateNetwork, error) {
+	m.record("Details", privateNetworkID)
+	if m.DetailsFunc != nil {
+		return m.DetailsFunc(ctx, privateNetworkID)
+	}
+	return new(glesys.PrivateNetwork), nil
+}
+
+// List records the call and calls ListFunc.
+func (m *PrivateNetworkAPI) List(ctx context.Context) (*[]glesys.PrivateNetwork, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return new([]glesys.PrivateNetwork), nil
+}
+
+// Destroy records the call and calls DestroyFunc.
+func (m *PrivateNetworkAPI) Destroy(ctx context.Context, privateNetworkID string) error {
+	m.record("Destroy", privateNetworkID)
+	if m.DestroyFunc != nil {
+		return m.DestroyFunc(ctx, privateNetworkID)
+	}
+	return nil
+}
+
+// Edit records the call and calls EditFunc.
+func (m *PrivateNetworkAPI) Edit(ctx context.Context, params glesys.EditPrivateNetworkParams) (*glesys.PrivateNetwork, error) {
+	m.record("Edit", params)
+	if m.EditFunc != nil {
+		return m.EditFunc(ctx, params)
+	}
+	return new(glesys.PrivateNetwork), nil
+}
+
+// EstimatedCost records the call and calls EstimatedCostFunc.
+func (m *PrivateNetworkAPI) EstimatedCost(ctx context.Context, privateNetworkID string) (*glesys.PrivateNetworkBilling, error) {
+	m.record("EstimatedCost", privateNetworkID)
+	if m.EstimatedCostFunc != nil {
+		return m.EstimatedCostFunc(ctx, privateNetworkID)
+	}
+	return new(glesys.PrivateNetworkBilling), nil
+}
+
+// CreateSegment records the call and calls CreateSegmentFunc.
+func (m *PrivateNetworkAPI) CreateSegment(ctx context.Context, params glesys.CreatePrivateNetworkSegmentParams) (*glesys.PrivateNetworkSegment, error) {
+	m.record("CreateSegment", params)
+	if m.CreateSegmentFunc != nil {
+		return m.CreateSegmentFunc(ctx, params)
+	}
+	return new(glesys.PrivateNetworkSegment), nil
+}
+
+// EditSegment records the call and calls EditSegmentFunc.
+func (m *PrivateNetworkAPI) EditSegment(ctx context.Context, params glesys.EditPrivateNetworkSegmentParams) (*glesys.PrivateNetworkSegment, error) {
+	m.record("EditSegment", params)
+	if m.EditSegmentFunc != nil {
+		return m.EditSegmentFunc(ctx, params)
+	}
+	return new(glesys.PrivateNetworkSegment), nil
+}
+
+// ListSegments records the call and calls ListSegmentsFunc.
+func (m *PrivateNetworkAPI) ListSegments(ctx context.Context, privatenetworkid string) (*[]glesys.PrivateNetworkSegment, error) {
+	m.record("ListSegments", privatenetworkid)
+	if m.ListSegmentsFunc != nil {
+		return m.ListSegmentsFunc(ctx, privatenetworkid)
+	}
+	return new([]glesys.PrivateNetworkSegment), nil
+}
+
+// DestroySegment records the call and calls DestroySegmentFunc.
+func (m *PrivateNetworkAPI) DestroySegment(ctx context.Context, id string) error {
+	m.record("DestroySegment", id)
+	if m.DestroySegmentFunc != nil {
+		return m.DestroySegmentFunc(ctx, id)
+	}
+	return nil
+}
+
+// ServerAPI is a programmable fake of glesys.ServerAPI.
+type ServerAPI struct {
+	Recorder
+
+	// AllowedArgumentsFunc is called by AllowedArguments if set.
+	AllowedArgumentsFunc func(ctx context.Context) (*glesys.ServerAllowedArguments, error)
+	// CreateFunc is called by Create if set.
+	CreateFunc func(ctx context.Context, params glesys.CreateServerParams) (*glesys.ServerDetails, error)
+	// CloneFunc is called by Clone if set.
+	CloneFunc func(ctx context.Context, serverID string, params glesys.CloneServerParams) (*glesys.ServerDetails, error)
+	// ConsoleFunc is called by Console if set.
+	ConsoleFunc func(ctx context.Context, serverID string) (*glesys.ServerConsoleDetails, error)
+	// CostsFunc is called by Costs if set.
+	CostsFunc func(ctx context.Context, serverID string) (*glesys.ServerCosts, error)
+	// DestroyFunc is called by Destroy if set.
+	DestroyFunc func(ctx context.Context, serverID string, params glesys.DestroyServerParams) error
+	// DetailsFunc is called by Details if set.
+	DetailsFunc func(ctx context.Context, serverID string) (*glesys.ServerDetails, error)
+	// EditFunc is called by Edit if set.
+	EditFunc func(ctx context.Context, serverID string, params glesys.EditServerParams) (*glesys.ServerDetails, error)
+	// EstimatedCostFunc is called by EstimatedCost if set.
+	EstimatedCostFunc func(ctx context.Context, params glesys.CreateServerParams) (*glesys.ServerCosts, error)
+	// ListFunc is called by List if set.
+	ListFunc func(ctx context.Context) (*[]glesys.Server, error)
+	// NetworkAdaptersFunc is called by NetworkAdapters if set.
+	NetworkAdaptersFunc func(ctx context.Context, serverID string) (*[]glesys.NetworkAdapter, error)
+	// PreviewCloudConfigFunc is called by PreviewCloudConfig if set.
+	PreviewCloudConfigFunc func(ctx context.Context, params glesys.PreviewCloudConfigParams) (*glesys.CloudConfigPreview, error)
+	// ResourceUsageFunc is called by ResourceUsage if set.
+	ResourceUsageFunc func(ctx context.Context, serverID string, params glesys.ServerResourceUsageParams) (*glesys.ServerResourceUsage, error)
+	// ListISOsFunc is called by ListISOs if set.
+	ListISOsFunc func(ctx context.Context, serverID string) (*[]string, error)
+	// MountISOFunc is called by MountISO if set.
+	MountISOFunc func(ctx context.Context, serverID string, isoFile string) (*glesys.ServerDetails, error)
+	// TemplatesFunc is called by Templates if set.
+	TemplatesFunc func(ctx context.Context) (*glesys.ServerPlatformTemplates, error)
+	// StartFunc is called by Start if set.
+	StartFunc func(ctx context.Context, serverID string) error
+	// StatusFunc is called by Status if set.
+	StatusFunc func(ctx context.Context, serverID string) (*glesys.ServerStatus, error)
+	// StopFunc is called by Stop if set.
+	StopFunc func(ctx context.Context, serverID string, params glesys.StopServerParams) error
+}
+
+var _ glesys.ServerAPI = (*ServerAPI)(nil)
+
+// AllowedArguments records the call and calls AllowedArgumentsFunc.
+func (m *ServerAPI) AllowedArguments(ctx context.Context) (*glesys.ServerAllowedArguments, error) {
+	m.record("AllowedArguments")
+	if m.AllowedArgumentsFunc != nil {
+		return m.AllowedArgumentsFunc(ctx)
+	}
+	return new(glesys.ServerAllowedArguments), nil
+}
+
+// Create records the call and calls CreateFunc.
+func (m *ServerAPI) Create(ctx context.Context, params glesys.CreateServerParams) (*glesys.ServerDetails, error) {
+	m.record("Create", params)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, params)
+	}
+	return new(glesys.ServerDetails), nil
+}
+
+// Clone records the call and calls CloneFunc.
+func (m *ServerAPI) Clone(ctx context.Context, serverID string, params glesys.CloneServerParams) (*glesys.ServerDetails, error) {
+	m.record("Clone", serverID, params)
+	if m.CloneFunc != nil {
+		return m.CloneFunc(ctx, serverID, params)
+	}
+	return new(glesys.ServerDetails), nil
+}
+
+// Console records the call and calls ConsoleFunc.
+func (m *ServerAPI) Console(ctx context.Context, serverID string) (*glesys.ServerConsoleDetails, error) {
+	m.record("Console", serverID)
+	if m.ConsoleFunc != nil {
+		return m.ConsoleFunc(ctx, serverID)
+	}
+	return new(glesys.ServerConsoleDetails), nil
+}
+
+// Costs records the call and calls CostsFunc.
+func (m *ServerAPI) Costs(ctx context.Context, serverID string) (*glesys.ServerCosts, error) {
+	m.record("Costs", serverID)
+	if m.CostsFunc != nil {
+		return m.CostsFunc(ctx, serverID)
+	}
+	return new(glesys.ServerCosts), nil
+}
+
+// Destroy records the call and calls DestroyFunc.
+func (m *ServerAPI) Destroy(ctx context.Context, serverID string, params glesys.DestroyServerParams) error {
+	m.record("Destroy", serverID, params)
+	if m.DestroyFunc != nil {
+		return m.DestroyFunc(ctx, serverID, params)
+	}
+	return nil
+}
+
+// Details records the call and calls DetailsFunc.
+func (m *ServerAPI) Details(ctx context.Context, serverID string) (*glesys.ServerDetails, error) {
+	m.record("Details", serverID)
+	if m.DetailsFunc != nil {
+		return m.DetailsFunc(ctx, serverID)
+	}
+	return new(glesys.ServerDetails), nil
+}
+
+// Edit records the call and calls EditFunc.
+func (m *ServerAPI) Edit(ctx context.Context, serverID string, params glesys.EditServerParams) (*glesys.ServerDetails, error) {
+	m.record("Edit", serverID, params)
+	if m.EditFunc != nil {
+		return m.EditFunc(ctx, serverID, params)
+	}
+	return new(glesys.ServerDetails), nil
+}
+
+// EstimatedCost records the call and calls EstimatedCostFunc.
+func (m *ServerAPI) EstimatedCost(ctx context.Context, params glesys.CreateServerParams) (*glesys.ServerCosts, error) {
+	m.record("EstimatedCost", params)
+	if m.EstimatedCostFunc != nil {
+		return m.EstimatedCostFunc(ctx, params)
+	}
+	return new(glesys.ServerCosts), nil
+}
+
+// List records the call and calls ListFunc.
+func (m *ServerAPI) List(ctx context.Context) (*[]glesys.Server, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return new([]glesys.Server), nil
+}
+
+// NetworkAdapters records the call and calls NetworkAdaptersFunc.
+func (m *ServerAPI) NetworkAdapters(ctx context.Context, serverID string) (*[]glesys.NetworkAdapter, error) {
+	m.record("NetworkAdapters", serverID)
+	if m.NetworkAdaptersFunc != nil {
+		return m.NetworkAdaptersFunc(ctx, serverID)
+	}
+	return new([]glesys.NetworkAdapter), nil
+}
+
+// PreviewCloudConfig records the call and calls PreviewCloudConfigFunc.
+func (m *ServerAPI) PreviewCloudConfig(ctx context.Context, params glesys.PreviewCloudConfigParams) (*glesys.CloudConfigPreview, error) {
+	m.record("PreviewCloudConfig", params)
+	if m.PreviewCloudConfigFunc != nil {
+		return m.PreviewCloudConfigFunc(ctx, params)
+	}
+	return new(glesys.CloudConfigPreview), nil
+}
+
+// ResourceUsage records the call and calls ResourceUsageFunc.
+func (m *ServerAPI) ResourceUsage(ctx context.Context, serverID string, params glesys.ServerResourceUsageParams) (*glesys.ServerResourceUsage, error) {
+	m.record("ResourceUsage", serverID, params)
+	if m.ResourceUsageFunc != nil {
+		return m.ResourceUsageFunc(ctx, serverID, params)
+	}
+	return new(glesys.ServerResourceUsage), nil
+}
+
+// ListISOs records the call and calls ListISOsFunc.
+func (m *ServerAPI) ListISOs(ctx context.Context, serverID string) (*[]string, error) {
+	m.record("ListISOs", serverID)
+	if m.ListISOsFunc != nil {
+		return m.ListISOsFunc(ctx, serverID)
+	}
+	return new([]string), nil
+}
+
+// MountISO records the call and calls MountISOFunc.
+func (m *ServerAPI) MountISO(ctx context.Context, serverID string, isoFile string) (*glesys.ServerDetails, error) {
+	m.record("MountISO", serverID, isoFile)
+	if m.MountISOFunc != nil {
+		return m.MountISOFunc(ctx, serverID, isoFile)
+	}
+	return new(glesys.ServerDetails), nil
+}
+
+// Templates records the call and calls TemplatesFunc.
+func (m *ServerAPI) Templates(ctx context.Context) (*glesys.ServerPlatformTemplates, error) {
+	m.record("Templates")
+	if m.TemplatesFunc != nil {
+		return m.TemplatesFunc(ctx)
+	}
+	return new(glesys.ServerPlatformTemplates), nil
+}
+
+// Start records the call and calls StartFunc.
+func (m *ServerAPI) Start(ctx context.Context, serverID string) error {
+	m.record("Start", serverID)
+	if m.StartFunc != nil {
+		return m.StartFunc(ctx, serverID)
+	}
+	return nil
+}
+
+// Status records the call and calls StatusFunc.
+func (m *ServerAPI) Status(ctx context.Context, serverID string) (*glesys.ServerStatus, error) {
+	m.record("Status", serverID)
+	if m.StatusFunc != nil {
+		return m.StatusFunc(ctx, serverID)
+	}
+	return new(glesys.ServerStatus), nil
+}
+
+// Stop records the call and calls StopFunc.
+func (m *ServerAPI) Stop(ctx context.Context, serverID string, params glesys.StopServerParams) error {
+	m.record("Stop", serverID, params)
+	if m.StopFunc != nil {
+		return m.StopFunc(ctx, serverID, params)
+	}
+	return nil
+}
+
+// ServerDisksAPI is a programmable fake of glesys.ServerDisksAPI.
+type ServerDisksAPI struct {
+	Recorder
+
+	// CreateFunc is called by Create if set.
+	CreateFunc func(ctx context.Context, params glesys.CreateServerDiskParams) (*glesys.ServerDiskDetails, error)
+	// UpdateNameFunc is called by UpdateName if set.
+	UpdateNameFunc func(ctx context.Context, params glesys.EditServerDiskParams) (*glesys.ServerDiskDetails, error)
+	// ReconfigureFunc is called by Reconfigure if set.
+	ReconfigureFunc func(ctx context.Context, params glesys.EditServerDiskParams) (*glesys.ServerDiskDetails, error)
+	// DeleteFunc is called by Delete if set.
+	DeleteFunc func(ctx context.Context, diskID string) error
+	// LimitsFunc is called by Limits if set.
+	LimitsFunc func(ctx context.Context, serverID string) (*glesys.ServerDiskLimitsDetails, error)
+}
+
+var _ glesys.ServerDisksAPI = (*ServerDisksAPI)(nil)
+
+// Create records the call and calls CreateFunc.
+func (m *ServerDisksAPI) Create(ctx context.Context, params glesys.CreateServerDiskParams) (*glesys.ServerDiskDetails, error) {
+	m.record("Create", params)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, params)
+	}
+	return new(glesys.ServerDiskDetails), nil
+}
+
+// UpdateName records the call and calls UpdateNameFunc.
+func (m *ServerDisksAPI) UpdateName(ctx context.Context, params glesys.EditServerDiskParams) (*glesys.ServerDiskDetails, error) {
+	m.record("UpdateName", params)
+	if m.UpdateNameFunc != nil {
+		return m.UpdateNameFunc(ctx, params)
+	}
+	return new(glesys.ServerDiskDetails), nil
+}
+
+// Reconfigure records the call and calls ReconfigureFunc.
+func (m *ServerDisksAPI) Reconfigure(ctx context.Context, params glesys.EditServerDiskParams) (*glesys.ServerDiskDetails, error) {
+	m.record("Reconfigure", params)
+	if m.ReconfigureFunc != nil {
+		return m.ReconfigureFunc(ctx, params)
+	}
+	return new(glesys.ServerDiskDetails), nil
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *ServerDisksAPI) Delete(ctx context.Context, diskID string) error {
+	m.record("Delete", diskID)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, diskID)
+	}
+	return nil
+}
+
+// Limits records the call and calls LimitsFunc.
+func (m *ServerDisksAPI) Limits(ctx context.Context, serverID string) (*glesys.ServerDiskLimitsDetails, error) {
+	m.record("Limits", serverID)
+	if m.LimitsFunc != nil {
+		return m.LimitsFunc(ctx, serverID)
+	}
+	return new(glesys.ServerDiskLimitsDetails), nil
+}
+
+// UserAPI is a programmable fake of glesys.UserAPI.
+type UserAPI struct {
+	Recorder
+
+	// DoOTPLoginFunc is called by DoOTPLogin if set.
+	DoOTPLoginFunc func(ctx context.Context, username string, password string, otp string) (*glesys.LoginDetailsResponse, error)
+	// LoginWithTOTPSecretFunc is called by LoginWithTOTPSecret if set.
+	LoginWithTOTPSecretFunc func(ctx context.Context, username string, password string, secret string) (*glesys.LoginDetailsResponse, error)
+	// ListOrganizationsFunc is called by ListOrganizations if set.
+	ListOrganizationsFunc func(ctx context.Context) (*[]glesys.UserOrganization, error)
+	// ListCustomerProjectsFunc is called by ListCustomerProjects if set.
+	ListCustomerProjectsFunc func(ctx context.Context, organizationNumber string) (*[]glesys.CustomerProject, error)
+}
+
+var _ glesys.UserAPI = (*UserAPI)(nil)
+
+// DoOTPLogin records the call and calls DoOTPLoginFunc.
+func (m *UserAPI) DoOTPLogin(ctx context.Context, username string, password string, otp string) (*glesys.LoginDetailsResponse, error) {
+	m.record("DoOTPLogin", username, password, otp)
+	if m.DoOTPLoginFunc != nil {
+		return m.DoOTPLoginFunc(ctx, username, password, otp)
+	}
+	return new(glesys.LoginDetailsResponse), nil
+}
+
+// LoginWithTOTPSecret records the call and calls LoginWithTOTPSecretFunc.
+func (m *UserAPI) LoginWithTOTPSecret(ctx context.Context, username string, password string, secret string) (*glesys.LoginDetailsResponse, error) {
+	m.record("LoginWithTOTPSecret", username, password, secret)
+	if m.LoginWithTOTPSecretFunc != nil {
+		return m.LoginWithTOTPSecretFunc(ctx, username, password, secret)
+	}
+	return new(glesys.LoginDetailsResponse), nil
+}
+
+// ListOrganizations records the call and calls ListOrganizationsFunc.
+func (m *UserAPI) ListOrganizations(ctx context.Context) (*[]glesys.UserOrganization, error) {
+	m.record("ListOrganizations")
+	if m.ListOrganizationsFunc != nil {
+		return m.ListOrganizationsFunc(ctx)
+	}
+	return new([]glesys.UserOrganization), nil
+}
+
+// ListCustomerProjects records the call and calls ListCustomerProjectsFunc.
+func (m *UserAPI) ListCustomerProjects(ctx context.Context, organizationNumber string) (*[]glesys.CustomerProject, error) {
+	m.record("ListCustomerProjects", organizationNumber)
+	if m.ListCustomerProjectsFunc != nil {
+		return m.ListCustomerProjectsFunc(ctx, organizationNumber)
+	}
+	return new([]glesys.CustomerProject), nil
+}
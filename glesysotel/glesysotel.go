@@ -0,0 +1,134 @@
+// Package glesysotel provides OpenTelemetry tracing for glesys-go.
+//
+// A client span is started for every API call, named after the endpoint,
+// e.g. "glesys server/create". The span is a child of the span in the context
+// passed to the service method.
+//
+//	client, err := glesys.NewClientWithOptions("CL12345", "your-api-key",
+//		glesys.WithMiddleware(glesysotel.Middleware()),
+//	)
+package glesysotel
+
+import (
+	"encoding/json"
+	"errors"
+
+	glesys "github.com/glesys/glesys-go/v8"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name of the tracer.
+const ScopeName = "github.com/glesys/glesys-go/glesysotel"
+
+// Attribute keys recorded on spans in addition to the semantic conventions
+// for HTTP clients.
+const (
+	EndpointKey   = attribute.Key("glesys.endpoint")
+	ProjectKey    = attribute.Key("glesys.project")
+	StatusCodeKey = attribute.Key("glesys.status.code")
+	AttemptsKey   = attribute.Key("glesys.attempts")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures the tracing middleware.
+type Option func(*config)
+
+// WithTracerProvider sets the TracerProvider used to create spans. Defaults
+// to the global TracerProvider.
+func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tracerProvider
+	}
+}
+
+// WithPropagators sets the propagators used to inject the span context into
+// the HTTP headers of each request. Defaults to the global propagators.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = propagators
+	}
+}
+
+// Middleware returns a glesys.Middleware which traces every API call.
+func Middleware(opts ...Option) glesys.Middleware {
+	c := config{
+		tracerProvider: otel.GetTracerProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	tracer := c.tracerProvider.Tracer(ScopeName)
+
+	return func(next glesys.Handler) glesys.Handler {
+		return func(request *glesys.Request) (*glesys.Response, error) {
+			endpoint := request.Endpoint()
+
+			attrs := []attribute.KeyValue{
+				attribute.String("http.request.method", request.Method),
+				attribute.String("url.full", request.HTTPRequest.URL.Redacted()),
+				attribute.String("server.address", request.HTTPRequest.URL.Hostname()),
+				EndpointKey.String(endpoint),
+			}
+			if request.Project != "" {
+				attrs = append(attrs, ProjectKey.String(request.Project))
+			}
+
+			ctx, span := tracer.Start(request.HTTPRequest.Context(), "glesys "+endpoint,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+			)
+			defer span.End()
+
+			request.HTTPRequest = request.HTTPRequest.WithContext(ctx)
+			c.propagators.Inject(ctx, propagation.HeaderCarrier(request.HTTPRequest.Header))
+
+			response, err := next(request)
+
+			if response != nil {
+				if response.HTTPResponse != nil {
+					span.SetAttributes(attribute.Int("http.response.status_code", response.HTTPResponse.StatusCode))
+				}
+				if code := statusCode(response.Body); code != 0 {
+					span.SetAttributes(StatusCodeKey.Int(code))
+				}
+				span.SetAttributes(AttemptsKey.Int(response.Attempts))
+			}
+
+			if err != nil {
+				var apiErr *glesys.APIError
+				if errors.As(err, &apiErr) && apiErr.Code != 0 {
+					span.SetAttributes(StatusCodeKey.Int(apiErr.Code))
+				}
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			return response, err
+		}
+	}
+}
+
+// statusCode returns `response.status.code` of a response body.
+func statusCode(body []byte) int {
+	data := struct {
+		Response struct {
+			Status struct {
+				Code int `json:"code"`
+			} `json:"status"`
+		} `json:"response"`
+	}{}
+	if json.Unmarshal(body, &data) != nil {
+		return 0
+	}
+	return data.Response.Status.Code
+}
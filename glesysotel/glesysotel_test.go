@@ -0,0 +1,103 @@
+package glesysotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	glesys "github.com/glesys/glesys-go/v8"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*glesys.Client, *tracetest.InMemoryExporter, *sdktrace.TracerProvider) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	client, err := glesys.NewClientWithOptions("CL12345", "api-key",
+		glesys.WithBaseURL(server.URL),
+		glesys.WithRetryPolicy(glesys.RetryPolicy{MaxAttempts: 2}),
+		glesys.WithMiddleware(Middleware(
+			WithTracerProvider(tracerProvider),
+			WithPropagators(propagation.TraceContext{}),
+		)),
+	)
+	assert.NoError(t, err)
+
+	return client, exporter, tracerProvider
+}
+
+func attributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, attr := range span.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+	return attrs
+}
+
+func TestMiddlewareStartsSpan(t *testing.T) {
+	var traceparent string
+	client, exporter, tracerProvider := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		w.Write([]byte(`{ "response": { "status": { "code": 200, "text": "OK" }, "server": { "serverid": "kvm12345" } } }`))
+	})
+
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "parent")
+	_, err := client.Servers.Create(ctx, glesys.CreateServerParams{})
+	parent.End()
+
+	assert.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+
+	span := spans[0]
+	assert.Equal(t, "glesys server/create", span.Name, "span name is correct")
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind, "span kind is correct")
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID(), "span is a child of the caller's span")
+	assert.Contains(t, traceparent, span.SpanContext.SpanID().String(), "span context is propagated")
+
+	attrs := attributes(span)
+	assert.Equal(t, "CL12345", attrs[ProjectKey].AsString(), "project is recorded")
+	assert.Equal(t, "server/create", attrs[EndpointKey].AsString(), "endpoint is recorded")
+	assert.Equal(t, int64(200), attrs["http.response.status_code"].AsInt64(), "http status is recorded")
+	assert.Equal(t, int64(200), attrs[StatusCodeKey].AsInt64(), "glesys status code is recorded")
+	assert.Equal(t, int64(1), attrs[AttemptsKey].AsInt64(), "attempts are recorded")
+}
+
+func TestMiddlewareRecordsErrorsAndRetries(t *testing.T) {
+	calls := 0
+	client, exporter, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{ "response": { "status": { "code": 429, "text": "Too many requests" } } }`))
+	})
+
+	_, err := client.Servers.Details(context.Background(), "kvm12345")
+
+	assert.ErrorIs(t, err, glesys.ErrRateLimited)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, "glesys server/details", span.Name, "span name is correct")
+	assert.Equal(t, codes.Error, span.Status.Code, "span status is error")
+	assert.Len(t, span.Events, 1, "error is recorded")
+
+	attrs := attributes(span)
+	assert.Equal(t, int64(429), attrs["http.response.status_code"].AsInt64(), "http status is recorded")
+	assert.Equal(t, int64(429), attrs[StatusCodeKey].AsInt64(), "glesys status code is recorded")
+	assert.Equal(t, int64(2), attrs[AttemptsKey].AsInt64(), "retries are recorded")
+	assert.Equal(t, 2, calls, "request was retried")
+}
@@ -0,0 +1,27 @@
+module github.com/glesys/glesys-go/glesysotel
+
+go 1.23.0
+
+require (
+	github.com/glesys/glesys-go/v8 v8.6.0
+	github.com/stretchr/testify v1.12.1
+	go.opentelemetry.io/otel v1.38.0
+	go.opentelemetry.io/otel/sdk v1.38.0
+	go.opentelemetry.io/otel/trace v1.38.0
+)
+
+require (
+	dario.cat/mergo v1.0.2 // indirect
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel/metric v1.38.0 // indirect
+	go.yaml.in/yaml/v3 v3.0.5 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+)
+
+// The replace directive only applies when developing in this repository,
+// consumers use the required version, which has to contain the middleware
+// API. Tag github.com/glesys/glesys-go/v8 before tagging this module.
+replace github.com/glesys/glesys-go/v8 => ../
@@ -0,0 +1,132 @@
+// Package glesysprom provides a Prometheus implementation of
+// glesys.MetricsRecorder.
+//
+//	collector := glesysprom.NewCollector(glesysprom.Opts{})
+//	prometheus.MustRegister(collector)
+//
+//	client, err := glesys.NewClientWithOptions("CL12345", "your-api-key",
+//		glesys.WithMetrics(collector),
+//	)
+package glesysprom
+
+import (
+	"strconv"
+	"time"
+
+	glesys "github.com/glesys/glesys-go/v8"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Opts configures a Collector.
+type Opts struct {
+	// Namespace of all metrics. Defaults to "glesys".
+	Namespace string
+	// ConstLabels are added to all metrics.
+	ConstLabels prometheus.Labels
+	// DurationBuckets are the buckets of the call duration histogram.
+	// Defaults to prometheus.DefBuckets.
+	DurationBuckets []float64
+	// WaitBuckets are the buckets of the rate limit wait histogram. Defaults
+	// to prometheus.DefBuckets.
+	WaitBuckets []float64
+}
+
+// Collector records metrics about API calls. It implements both
+// glesys.MetricsRecorder and prometheus.Collector.
+type Collector struct {
+	calls         *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	errors        *prometheus.CounterVec
+	retries       *prometheus.CounterVec
+	rateLimitWait *prometheus.HistogramVec
+}
+
+var _ glesys.MetricsRecorder = (*Collector)(nil)
+var _ prometheus.Collector = (*Collector)(nil)
+
+// NewCollector creates a new Collector. It has to be registered with a
+// prometheus.Registerer before its metrics are exported.
+func NewCollector(opts Opts) *Collector {
+	if opts.Namespace == "" {
+		opts.Namespace = "glesys"
+	}
+	if opts.DurationBuckets == nil {
+		opts.DurationBuckets = prometheus.DefBuckets
+	}
+	if opts.WaitBuckets == nil {
+		opts.WaitBuckets = prometheus.DefBuckets
+	}
+
+	return &Collector{
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "api_calls_total",
+			Help:        "Number of API calls by endpoint, method and HTTP status code.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"endpoint", "method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Name:        "api_call_duration_seconds",
+			Help:        "Duration of API calls including retries.",
+			ConstLabels: opts.ConstLabels,
+			Buckets:     opts.DurationBuckets,
+		}, []string{"endpoint", "method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "api_errors_total",
+			Help:        "Number of failed API calls by endpoint and HTTP status code. The code is 0 if no response was received.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"endpoint", "code"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "api_retries_total",
+			Help:        "Number of retried requests by endpoint.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"endpoint"}),
+		rateLimitWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Name:        "rate_limit_wait_seconds",
+			Help:        "Time requests waited for the client-side rate limiter.",
+			ConstLabels: opts.ConstLabels,
+			Buckets:     opts.WaitBuckets,
+		}, []string{"endpoint"}),
+	}
+}
+
+// RecordCall implements glesys.MetricsRecorder.
+func (c *Collector) RecordCall(call glesys.CallMetrics) {
+	code := strconv.Itoa(call.StatusCode)
+
+	c.calls.WithLabelValues(call.Endpoint, call.Method, code).Inc()
+	c.duration.WithLabelValues(call.Endpoint, call.Method).Observe(call.Duration.Seconds())
+
+	if call.Err != nil {
+		c.errors.WithLabelValues(call.Endpoint, code).Inc()
+	}
+	if call.Attempts > 1 {
+		c.retries.WithLabelValues(call.Endpoint).Add(float64(call.Attempts - 1))
+	}
+}
+
+// RecordRateLimitWait implements glesys.MetricsRecorder.
+func (c *Collector) RecordRateLimitWait(endpoint string, wait time.Duration) {
+	c.rateLimitWait.WithLabelValues(endpoint).Observe(wait.Seconds())
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.calls.Describe(ch)
+	c.duration.Describe(ch)
+	c.errors.Describe(ch)
+	c.retries.Describe(ch)
+	c.rateLimitWait.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.calls.Collect(ch)
+	c.duration.Collect(ch)
+	c.errors.Collect(ch)
+	c.retries.Collect(ch)
+	c.rateLimitWait.Collect(ch)
+}
@@ -0,0 +1,73 @@
+package glesysprom
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	glesys "github.com/glesys/glesys-go/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectorRecordCall(t *testing.T) {
+	collector := NewCollector(Opts{})
+
+	collector.RecordCall(glesys.CallMetrics{Method: "GET", Endpoint: "server/details", StatusCode: 200, Duration: time.Second, Attempts: 1})
+	collector.RecordCall(glesys.CallMetrics{Method: "POST", Endpoint: "server/create", StatusCode: 429, Duration: time.Second, Attempts: 3, Err: glesys.ErrRateLimited})
+	collector.RecordRateLimitWait("server/create", 500*time.Millisecond)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(collector.errors), "successful calls are not errors")
+	assert.Equal(t, 1, testutil.CollectAndCount(collector.retries), "calls without retries are not counted")
+	assert.Equal(t, 1.0, testutil.ToFloat64(collector.calls.WithLabelValues("server/details", "GET", "200")), "call is counted")
+	assert.Equal(t, 1.0, testutil.ToFloat64(collector.errors.WithLabelValues("server/create", "429")), "error is counted")
+	assert.Equal(t, 2.0, testutil.ToFloat64(collector.retries.WithLabelValues("server/create")), "retries are counted")
+
+	expected := `
+# HELP glesys_rate_limit_wait_seconds Time requests waited for the client-side rate limiter.
+# TYPE glesys_rate_limit_wait_seconds histogram
+glesys_rate_limit_wait_seconds_bucket{endpoint="server/create",le="0.005"} 0
+glesys_rate_limit_wait_seconds_bucket{endpoint="server/create",le="0.01"} 0
+glesys_rate_limit_wait_seconds_bucket{endpoint="server/create",le="0.025"} 0
+glesys_rate_limit_wait_seconds_bucket{endpoint="server/create",le="0.05"} 0
+glesys_rate_limit_wait_seconds_bucket{endpoint="server/create",le="0.1"} 0
+glesys_rate_limit_wait_seconds_bucket{endpoint="server/create",le="0.25"} 0
+glesys_rate_limit_wait_seconds_bucket{endpoint="server/create",le="0.5"} 1
+glesys_rate_limit_wait_seconds_bucket{endpoint="server/create",le="1"} 1
+glesys_rate_limit_wait_seconds_bucket{endpoint="server/create",le="2.5"} 1
+glesys_rate_limit_wait_seconds_bucket{endpoint="server/create",le="5"} 1
+glesys_rate_limit_wait_seconds_bucket{endpoint="server/create",le="10"} 1
+glesys_rate_limit_wait_seconds_bucket{endpoint="server/create",le="+Inf"} 1
+glesys_rate_limit_wait_seconds_sum{endpoint="server/create"} 0.5
+glesys_rate_limit_wait_seconds_count{endpoint="server/create"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "glesys_rate_limit_wait_seconds"))
+}
+
+func TestCollectorWithClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{ "response": { "servers": [] } }`))
+	}))
+	defer server.Close()
+
+	registry := prometheus.NewPedanticRegistry()
+	collector := NewCollector(Opts{Namespace: "test", ConstLabels: prometheus.Labels{"app": "provisioning"}})
+	registry.MustRegister(collector)
+
+	client, err := glesys.NewClientWithOptions("CL12345", "api-key",
+		glesys.WithBaseURL(server.URL),
+		glesys.WithMetrics(collector),
+	)
+	assert.NoError(t, err)
+
+	client.Servers.List(context.Background())
+
+	count, err := testutil.GatherAndCount(registry, "test_api_calls_total", "test_api_call_duration_seconds")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count, "call metrics are exported")
+	assert.Equal(t, 1.0, testutil.ToFloat64(collector.calls.WithLabelValues("server/list", "GET", "200")), "call is counted")
+}
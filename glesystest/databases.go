@@ -0,0 +1,156 @@
+package glesystest
+
+import (
+	"fmt"
+
+	glesys "github.com/glesys/glesys-go/v8"
+)
+
+// databaseState is a database in the fake API. New databases have the status
+// "creating" until the configured number of details calls have been made.
+type databaseState struct {
+	details glesys.DatabaseDetails
+	polls   int
+}
+
+func init() {
+	handlers["database/connectiondetails"] = (*Server).databaseConnectionDetails
+	handlers["database/create"] = (*Server).createDatabase
+	handlers["database/delete"] = (*Server).deleteDatabase
+	handlers["database/details"] = (*Server).databaseDetails
+	handlers["database/list"] = (*Server).listDatabases
+	handlers["database/listplans"] = (*Server).listDatabasePlans
+	handlers["database/updateallowlist"] = (*Server).updateDatabaseAllowlist
+}
+
+func defaultDatabasePlans() []glesys.DatabasePlan {
+	return []glesys.DatabasePlan{
+		{Key: "plan-1core-4gib-25gib", CPUCores: 1, MemoryInGib: 4, StorageInGib: 25},
+		{Key: "plan-2core-8gib-50gib", CPUCores: 2, MemoryInGib: 8, StorageInGib: 50},
+		{Key: "plan-4core-16gib-100gib", CPUCores: 4, MemoryInGib: 16, StorageInGib: 100},
+	}
+}
+
+func (s *Server) database(r *request) (*databaseState, error) {
+	id := r.string("id")
+	database, ok := s.databases[id]
+	if !ok {
+		return nil, notFound("Database %s does not exist", id)
+	}
+	return database, nil
+}
+
+func (s *Server) createDatabase(r *request) (map[string]interface{}, error) {
+	var params glesys.CreateDatabaseParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	if params.Name == "" || params.Engine == "" {
+		return nil, badRequest("name and engine are required")
+	}
+
+	var plan *glesys.DatabasePlan
+	for i := range s.plans {
+		if s.plans[i].Key == params.PlanKey {
+			plan = &s.plans[i]
+		}
+	}
+	if plan == nil {
+		return nil, badRequest("Plan %s does not exist", params.PlanKey)
+	}
+
+	id := s.nextID("db-")
+	database := &databaseState{
+		details: glesys.DatabaseDetails{
+			DataCenterKey: params.DataCenterKey,
+			ID:            id,
+			Name:          params.Name,
+			Engine:        params.Engine,
+			EngineVersion: params.EngineVersion,
+			Fqdn:          fmt.Sprintf("%s.%s.db.example.com", params.Name, id),
+			Status:        "creating",
+			Allowlist:     params.AllowList,
+			Plan:          *plan,
+		},
+		polls: s.transitionPolls,
+	}
+	if database.details.Allowlist == nil {
+		database.details.Allowlist = []string{}
+	}
+	database.details.MaintenanceWindow.WeekDay = "sunday"
+	database.details.MaintenanceWindow.StartTime = "03:00"
+	database.details.MaintenanceWindow.DurationInMinutes = 60
+
+	s.databases[id] = database
+	return map[string]interface{}{"database": database.details}, nil
+}
+
+func (s *Server) deleteDatabase(r *request) (map[string]interface{}, error) {
+	database, err := s.database(r)
+	if err != nil {
+		return nil, err
+	}
+	delete(s.databases, database.details.ID)
+	return nil, nil
+}
+
+func (s *Server) databaseDetails(r *request) (map[string]interface{}, error) {
+	database, err := s.database(r)
+	if err != nil {
+		return nil, err
+	}
+	if database.details.Status == "creating" {
+		if database.polls > 0 {
+			database.polls--
+		} else {
+			database.details.Status = "running"
+		}
+	}
+	return map[string]interface{}{"database": database.details}, nil
+}
+
+func (s *Server) databaseConnectionDetails(r *request) (map[string]interface{}, error) {
+	database, err := s.database(r)
+	if err != nil {
+		return nil, err
+	}
+	details := glesys.ConnectionDetails{
+		ConnectionString: fmt.Sprintf("%s://admin:password@%s/%s", database.details.Engine, database.details.Fqdn, database.details.Name),
+	}
+	return map[string]interface{}{"connectiondetails": details}, nil
+}
+
+func (s *Server) listDatabases(r *request) (map[string]interface{}, error) {
+	databases := []glesys.Database{}
+	for _, id := range sortedKeys(s.databases) {
+		details := s.databases[id].details
+		databases = append(databases, glesys.Database{
+			DataCenterKey: details.DataCenterKey,
+			ID:            details.ID,
+			Name:          details.Name,
+			Engine:        details.Engine,
+			EngineVersion: details.EngineVersion,
+		})
+	}
+	return map[string]interface{}{"databases": databases}, nil
+}
+
+func (s *Server) listDatabasePlans(r *request) (map[string]interface{}, error) {
+	return map[string]interface{}{"plans": s.plans}, nil
+}
+
+func (s *Server) updateDatabaseAllowlist(r *request) (map[string]interface{}, error) {
+	database, err := s.database(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.UpdateAllowlistParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	database.details.Allowlist = params.AllowList
+	if database.details.Allowlist == nil {
+		database.details.Allowlist = []string{}
+	}
+	return map[string]interface{}{"database": database.details}, nil
+}
@@ -0,0 +1,57 @@
+package glesystest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	glesys "github.com/glesys/glesys-go/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseLifecycle(t *testing.T) {
+	_, client := newTestClient(t, WithTransitionPolls(1))
+	ctx := context.Background()
+
+	plans, _ := client.Databases.ListPlans(ctx)
+	database, err := client.Databases.Create(ctx, glesys.CreateDatabaseParams{
+		PlanKey:       (*plans)[0].Key,
+		Engine:        "postgresql",
+		EngineVersion: "16",
+		DataCenterKey: "dc-sto1",
+		Name:          "app",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "creating", database.Status)
+
+	database, _ = client.Databases.Details(ctx, database.ID)
+	assert.Equal(t, "creating", database.Status, "database is creating during transition")
+	database, _ = client.Databases.Details(ctx, database.ID)
+	assert.Equal(t, "running", database.Status, "database is running after transition")
+
+	database, _ = client.Databases.UpdateAllowlist(ctx, glesys.UpdateAllowlistParams{ID: database.ID, AllowList: []string{"192.0.2.0/24"}})
+	assert.Equal(t, []string{"192.0.2.0/24"}, database.Allowlist, "allowlist is updated")
+
+	details, err := client.Databases.ConnectionString(ctx, database.ID)
+	assert.NoError(t, err)
+	assert.Contains(t, details.ConnectionString, database.Fqdn)
+
+	_, err = client.Databases.Create(ctx, glesys.CreateDatabaseParams{PlanKey: "unknown", Engine: "mysql", Name: "app"})
+	assert.ErrorIs(t, err, glesys.ErrValidation, "plan has to exist")
+
+	assert.NoError(t, client.Databases.Delete(ctx, database.ID))
+	databases, _ := client.Databases.List(ctx)
+	assert.Empty(t, *databases, "database is deleted")
+}
+
+func TestDatabaseWaitUntilRunning(t *testing.T) {
+	_, client := newTestClient(t, WithTransitionPolls(2))
+	ctx := context.Background()
+
+	plans, _ := client.Databases.ListPlans(ctx)
+	database, _ := client.Databases.Create(ctx, glesys.CreateDatabaseParams{PlanKey: (*plans)[0].Key, Engine: "mysql", Name: "app"})
+
+	database, err := glesys.WaitUntilDatabaseRunning(ctx, client.Databases, database.ID, glesys.WithPollInterval(time.Millisecond, time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, "running", database.Status)
+}
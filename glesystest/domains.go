@@ -0,0 +1,196 @@
+package glesystest
+
+import (
+	"sort"
+
+	glesys "github.com/glesys/glesys-go/v8"
+)
+
+func init() {
+	handlers["domain/add"] = (*Server).addDomain
+	handlers["domain/addrecord"] = (*Server).addRecord
+	handlers["domain/delete"] = (*Server).deleteDomain
+	handlers["domain/deleterecord"] = (*Server).deleteRecord
+	handlers["domain/details"] = (*Server).domainDetails
+	handlers["domain/list"] = (*Server).listDomains
+	handlers["domain/listrecords"] = (*Server).listRecords
+	handlers["domain/updaterecord"] = (*Server).updateRecord
+}
+
+func (s *Server) domain(name string) (*glesys.DNSDomain, error) {
+	domain, ok := s.domains[name]
+	if !ok {
+		return nil, notFound("Domain %s does not exist", name)
+	}
+	domain.RecordCount = 0
+	for _, record := range s.records {
+		if record.DomainName == name {
+			domain.RecordCount++
+		}
+	}
+	return domain, nil
+}
+
+func (s *Server) record(id int) (*glesys.DNSDomainRecord, error) {
+	record, ok := s.records[id]
+	if !ok {
+		return nil, notFound("Record %d does not exist", id)
+	}
+	return record, nil
+}
+
+// addDomain adds a domain. Unlike the real API, no default records are
+// created.
+func (s *Server) addDomain(r *request) (map[string]interface{}, error) {
+	var params glesys.AddDNSDomainParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	if params.Name == "" {
+		return nil, badRequest("domainname is required")
+	}
+	if _, ok := s.domains[params.Name]; ok {
+		return nil, badRequest("Domain %s already exists", params.Name)
+	}
+
+	domain := &glesys.DNSDomain{
+		Name:              params.Name,
+		CreateTime:        s.now().UTC().Format("2006-01-02T15:04:05"),
+		DisplayName:       params.Name,
+		Expire:            orDefault(params.Expire, 1814400),
+		Minimum:           orDefault(params.Minimum, 10800),
+		PrimaryNameServer: "ns1.namesystem.se.",
+		Refresh:           orDefault(params.Refresh, 10800),
+		ResponsiblePerson: "registry.glesys.se.",
+		Retry:             orDefault(params.Retry, 2700),
+		TTL:               orDefault(params.TTL, 3600),
+	}
+	if params.PrimaryNameServer != "" {
+		domain.PrimaryNameServer = params.PrimaryNameServer
+	}
+	if params.ResponsiblePerson != "" {
+		domain.ResponsiblePerson = params.ResponsiblePerson
+	}
+
+	s.domains[params.Name] = domain
+	return map[string]interface{}{"domain": domain}, nil
+}
+
+func (s *Server) deleteDomain(r *request) (map[string]interface{}, error) {
+	domain, err := s.domain(r.string("domainname"))
+	if err != nil {
+		return nil, err
+	}
+	for id, record := range s.records {
+		if record.DomainName == domain.Name {
+			delete(s.records, id)
+		}
+	}
+	delete(s.domains, domain.Name)
+	return nil, nil
+}
+
+func (s *Server) domainDetails(r *request) (map[string]interface{}, error) {
+	domain, err := s.domain(r.string("domainname"))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"domain": domain}, nil
+}
+
+func (s *Server) listDomains(r *request) (map[string]interface{}, error) {
+	domains := []glesys.DNSDomain{}
+	for _, name := range sortedKeys(s.domains) {
+		domain, _ := s.domain(name)
+		domains = append(domains, *domain)
+	}
+	return map[string]interface{}{"domains": domains}, nil
+}
+
+func (s *Server) addRecord(r *request) (map[string]interface{}, error) {
+	var params glesys.AddRecordParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	domain, err := s.domain(params.DomainName)
+	if err != nil {
+		return nil, err
+	}
+	if params.Host == "" || params.Type == "" || params.Data == "" {
+		return nil, badRequest("host, type and data are required")
+	}
+
+	s.sequence++
+	record := &glesys.DNSDomainRecord{
+		DomainName: domain.Name,
+		Data:       params.Data,
+		Host:       params.Host,
+		RecordID:   s.sequence,
+		TTL:        orDefault(params.TTL, domain.TTL),
+		Type:       params.Type,
+	}
+	s.records[record.RecordID] = record
+	return map[string]interface{}{"record": record}, nil
+}
+
+func (s *Server) deleteRecord(r *request) (map[string]interface{}, error) {
+	var params struct {
+		RecordID int `json:"recordid"`
+	}
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	if _, err := s.record(params.RecordID); err != nil {
+		return nil, err
+	}
+	delete(s.records, params.RecordID)
+	return nil, nil
+}
+
+func (s *Server) listRecords(r *request) (map[string]interface{}, error) {
+	domain, err := s.domain(r.string("domainname"))
+	if err != nil {
+		return nil, err
+	}
+
+	records := []glesys.DNSDomainRecord{}
+	for _, record := range s.records {
+		if record.DomainName == domain.Name {
+			records = append(records, *record)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].RecordID < records[j].RecordID })
+	return map[string]interface{}{"records": records}, nil
+}
+
+func (s *Server) updateRecord(r *request) (map[string]interface{}, error) {
+	var params glesys.UpdateRecordParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	record, err := s.record(params.RecordID)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Data != "" {
+		record.Data = params.Data
+	}
+	if params.Host != "" {
+		record.Host = params.Host
+	}
+	if params.TTL != 0 {
+		record.TTL = params.TTL
+	}
+	if params.Type != "" {
+		record.Type = params.Type
+	}
+	return map[string]interface{}{"record": record}, nil
+}
+
+func orDefault(value, defaultValue int) int {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
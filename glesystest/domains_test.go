@@ -0,0 +1,43 @@
+package glesystest
+
+import (
+	"context"
+	"testing"
+
+	glesys "github.com/glesys/glesys-go/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainRecords(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	_, err := client.DNSDomains.AddRecord(ctx, glesys.AddRecordParams{DomainName: "example.com", Host: "www", Type: "A", Data: "192.0.2.1"})
+	assert.ErrorIs(t, err, glesys.ErrNotFound, "domain has to exist")
+
+	domain, err := client.DNSDomains.AddDNSDomain(ctx, glesys.AddDNSDomainParams{Name: "example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3600, domain.TTL, "default ttl is set")
+
+	record, err := client.DNSDomains.AddRecord(ctx, glesys.AddRecordParams{DomainName: "example.com", Host: "www", Type: "A", Data: "192.0.2.1"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3600, record.TTL, "record inherits ttl of domain")
+
+	record, err = client.DNSDomains.UpdateRecord(ctx, glesys.UpdateRecordParams{RecordID: record.RecordID, Data: "192.0.2.2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "192.0.2.2", record.Data, "record is updated")
+	assert.Equal(t, "www", record.Host, "host is unchanged")
+
+	records, _ := client.DNSDomains.ListRecords(ctx, "example.com")
+	assert.Equal(t, []glesys.DNSDomainRecord{*record}, *records)
+	domains, _ := client.DNSDomains.List(ctx)
+	assert.Equal(t, 1, (*domains)[0].RecordCount, "records are counted")
+
+	assert.NoError(t, client.DNSDomains.DeleteRecord(ctx, record.RecordID))
+	records, _ = client.DNSDomains.ListRecords(ctx, "example.com")
+	assert.Empty(t, *records, "record is deleted")
+
+	assert.NoError(t, client.DNSDomains.Delete(ctx, glesys.DeleteDNSDomainParams{Name: "example.com"}))
+	_, err = client.DNSDomains.Details(ctx, "example.com")
+	assert.ErrorIs(t, err, glesys.ErrNotFound, "domain is deleted")
+}
@@ -0,0 +1,183 @@
+package glesystest
+
+import (
+	"strings"
+
+	glesys "github.com/glesys/glesys-go/v8"
+)
+
+func init() {
+	handlers["email/createaccount"] = (*Server).createEmailAccount
+	handlers["email/createalias"] = (*Server).createEmailAlias
+	handlers["email/delete"] = (*Server).deleteEmail
+	handlers["email/editaccount"] = (*Server).editEmailAccount
+	handlers["email/editalias"] = (*Server).editEmailAlias
+	handlers["email/list"] = (*Server).listEmails
+}
+
+// emailDomain returns the domain of address, which has to be added with
+// domain/add first.
+func (s *Server) emailDomain(address string) (string, error) {
+	at := strings.LastIndex(address, "@")
+	if at < 1 || at == len(address)-1 {
+		return "", badRequest("Invalid email address %s", address)
+	}
+	domain := address[at+1:]
+	if _, ok := s.domains[domain]; !ok {
+		return "", notFound("Domain %s does not exist", domain)
+	}
+	return domain, nil
+}
+
+func (s *Server) emailExists(address string) bool {
+	_, account := s.emailAccounts[address]
+	_, alias := s.emailAliases[address]
+	return account || alias
+}
+
+func (s *Server) createEmailAccount(r *request) (map[string]interface{}, error) {
+	var params glesys.CreateAccountParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	if _, err := s.emailDomain(params.EmailAccount); err != nil {
+		return nil, err
+	}
+	if params.Password == "" {
+		return nil, badRequest("password is required")
+	}
+	if s.emailExists(params.EmailAccount) {
+		return nil, badRequest("%s already exists", params.EmailAccount)
+	}
+
+	account := &glesys.EmailAccount{
+		EmailAccount:         params.EmailAccount,
+		DisplayName:          params.EmailAccount,
+		QuotaInGiB:           orDefault(params.QuotaInGiB, 1),
+		AntiSpamLevel:        orDefault(params.AntiSpamLevel, 3),
+		AntiVirus:            "yes",
+		AutoRespond:          "no",
+		AutoRespondMessage:   params.AutoRespondMessage,
+		AutoRespondSaveEmail: "yes",
+		RejectSpam:           "no",
+		Created:              s.now().UTC().Format("2006-01-02T15:04:05"),
+	}
+	applyEmailAccountParams(account, glesys.EditAccountParams{
+		AntiVirus:   params.AntiVirus,
+		AutoRespond: params.AutoRespond,
+		RejectSpam:  params.RejectSpam,
+	})
+
+	s.emailAccounts[account.EmailAccount] = account
+	return map[string]interface{}{"emailaccount": account}, nil
+}
+
+func (s *Server) editEmailAccount(r *request) (map[string]interface{}, error) {
+	var params glesys.EditAccountParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	address := r.string("emailaccount")
+	account, ok := s.emailAccounts[address]
+	if !ok {
+		return nil, notFound("Email account %s does not exist", address)
+	}
+
+	applyEmailAccountParams(account, params)
+	account.Modified = s.now().UTC().Format("2006-01-02T15:04:05")
+	return map[string]interface{}{"emailaccount": account}, nil
+}
+
+func applyEmailAccountParams(account *glesys.EmailAccount, params glesys.EditAccountParams) {
+	if params.AntiSpamLevel != 0 {
+		account.AntiSpamLevel = params.AntiSpamLevel
+	}
+	if params.AntiVirus != "" {
+		account.AntiVirus = params.AntiVirus
+	}
+	if params.AutoRespond != "" {
+		account.AutoRespond = params.AutoRespond
+	}
+	if params.AutoRespondMessage != "" {
+		account.AutoRespondMessage = params.AutoRespondMessage
+	}
+	if params.QuotaInGiB != 0 {
+		account.QuotaInGiB = params.QuotaInGiB
+	}
+	if params.RejectSpam != "" {
+		account.RejectSpam = params.RejectSpam
+	}
+}
+
+func (s *Server) createEmailAlias(r *request) (map[string]interface{}, error) {
+	var params glesys.EmailAliasParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	if _, err := s.emailDomain(params.EmailAlias); err != nil {
+		return nil, err
+	}
+	if params.GoTo == "" {
+		return nil, badRequest("goto is required")
+	}
+	if s.emailExists(params.EmailAlias) {
+		return nil, badRequest("%s already exists", params.EmailAlias)
+	}
+
+	alias := &glesys.EmailAlias{
+		EmailAlias:  params.EmailAlias,
+		DisplayName: params.EmailAlias,
+		GoTo:        params.GoTo,
+	}
+	s.emailAliases[alias.EmailAlias] = alias
+	return map[string]interface{}{"alias": alias}, nil
+}
+
+func (s *Server) editEmailAlias(r *request) (map[string]interface{}, error) {
+	var params glesys.EmailAliasParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	alias, ok := s.emailAliases[params.EmailAlias]
+	if !ok {
+		return nil, notFound("Email alias %s does not exist", params.EmailAlias)
+	}
+	if params.GoTo != "" {
+		alias.GoTo = params.GoTo
+	}
+	return map[string]interface{}{"alias": alias}, nil
+}
+
+func (s *Server) deleteEmail(r *request) (map[string]interface{}, error) {
+	address := r.string("email")
+	if !s.emailExists(address) {
+		return nil, notFound("%s does not exist", address)
+	}
+	delete(s.emailAccounts, address)
+	delete(s.emailAliases, address)
+	return nil, nil
+}
+
+func (s *Server) listEmails(r *request) (map[string]interface{}, error) {
+	var params glesys.ListEmailsParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	domain := r.string("domainname")
+	if _, ok := s.domains[domain]; !ok {
+		return nil, notFound("Domain %s does not exist", domain)
+	}
+
+	list := glesys.EmailList{EmailAccounts: []glesys.EmailAccount{}, EmailAliases: []glesys.EmailAlias{}}
+	for _, address := range sortedKeys(s.emailAccounts) {
+		if strings.HasSuffix(address, "@"+domain) && strings.Contains(address, params.Filter) {
+			list.EmailAccounts = append(list.EmailAccounts, *s.emailAccounts[address])
+		}
+	}
+	for _, address := range sortedKeys(s.emailAliases) {
+		if strings.HasSuffix(address, "@"+domain) && strings.Contains(address, params.Filter) {
+			list.EmailAliases = append(list.EmailAliases, *s.emailAliases[address])
+		}
+	}
+	return map[string]interface{}{"list": list}, nil
+}
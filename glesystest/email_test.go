@@ -0,0 +1,46 @@
+package glesystest
+
+import (
+	"context"
+	"testing"
+
+	glesys "github.com/glesys/glesys-go/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailAccountsAndAliases(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	_, err := client.EmailDomains.CreateAccount(ctx, glesys.CreateAccountParams{EmailAccount: "alice@example.com", Password: "secret"})
+	assert.ErrorIs(t, err, glesys.ErrNotFound, "domain has to exist")
+
+	client.DNSDomains.AddDNSDomain(ctx, glesys.AddDNSDomainParams{Name: "example.com"})
+	account, err := client.EmailDomains.CreateAccount(ctx, glesys.CreateAccountParams{EmailAccount: "alice@example.com", Password: "secret"})
+	assert.NoError(t, err)
+	assert.Empty(t, account.Password, "password is not returned")
+	assert.Equal(t, 1, account.QuotaInGiB, "default quota is set")
+
+	account, _ = client.EmailDomains.EditAccount(ctx, "alice@example.com", glesys.EditAccountParams{QuotaInGiB: 5})
+	assert.Equal(t, 5, account.QuotaInGiB, "account is edited")
+
+	alias, err := client.EmailDomains.CreateAlias(ctx, glesys.EmailAliasParams{EmailAlias: "info@example.com", GoTo: "alice@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "alice@example.com", alias.GoTo)
+	_, err = client.EmailDomains.CreateAlias(ctx, glesys.EmailAliasParams{EmailAlias: "alice@example.com", GoTo: "bob@example.com"})
+	assert.ErrorIs(t, err, glesys.ErrValidation, "alias can not replace an account")
+
+	alias, _ = client.EmailDomains.EditAlias(ctx, glesys.EmailAliasParams{EmailAlias: "info@example.com", GoTo: "bob@example.com"})
+	assert.Equal(t, "bob@example.com", alias.GoTo, "alias is edited")
+
+	list, err := client.EmailDomains.List(ctx, "example.com", glesys.ListEmailsParams{})
+	assert.NoError(t, err)
+	assert.Len(t, list.EmailAccounts, 1)
+	assert.Len(t, list.EmailAliases, 1)
+
+	assert.NoError(t, client.EmailDomains.Delete(ctx, "alice@example.com"))
+	assert.NoError(t, client.EmailDomains.Delete(ctx, "info@example.com"))
+	list, _ = client.EmailDomains.List(ctx, "example.com", glesys.ListEmailsParams{})
+	assert.Empty(t, list.EmailAccounts, "account is deleted")
+	assert.Empty(t, list.EmailAliases, "alias is deleted")
+}
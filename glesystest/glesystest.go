@@ -0,0 +1,372 @@
+// Package glesystest provides an in-memory fake of the GleSYS API for
+// testing.
+//
+// The fake runs on an httptest.Server and keeps servers, IP addresses, DNS
+// domains, load balancers, email accounts, private networks and databases in
+// memory, so that complete provisioning workflows can run offline:
+//
+//	api := glesystest.NewServer()
+//	defer api.Close()
+//
+//	client := glesys.NewClient("CL12345", "your-api-key", "my-application/0.0.1")
+//	client.SetBaseURL(api.URL)
+//
+// Errors and latency can be injected with FailNext, SetLatency and AddHook.
+package glesystest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	glesys "github.com/glesys/glesys-go/v8"
+)
+
+// Fault is an error response returned by the fake API.
+type Fault struct {
+	StatusCode int
+	Text       string
+}
+
+func (f *Fault) Error() string {
+	return fmt.Sprintf("%d %s", f.StatusCode, f.Text)
+}
+
+func badRequest(format string, args ...interface{}) *Fault {
+	return &Fault{StatusCode: http.StatusBadRequest, Text: fmt.Sprintf(format, args...)}
+}
+
+func notFound(format string, args ...interface{}) *Fault {
+	return &Fault{StatusCode: http.StatusNotFound, Text: fmt.Sprintf(format, args...)}
+}
+
+func locked(format string, args ...interface{}) *Fault {
+	return &Fault{StatusCode: http.StatusLocked, Text: fmt.Sprintf(format, args...)}
+}
+
+// Hook is called before a request is handled. Returning an error aborts the
+// request with an error response. A *Fault sets the status code and text of
+// the response, other errors result in an internal server error.
+type Hook func(endpoint string, params map[string]interface{}) error
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithCredentials makes the server reject requests which are not
+// authenticated with project and apiKey.
+func WithCredentials(project, apiKey string) Option {
+	return func(s *Server) {
+		s.project = project
+		s.apiKey = apiKey
+	}
+}
+
+// WithFreeIPs replaces the default pool of IP addresses available for
+// reservation and assignment to servers and load balancers.
+func WithFreeIPs(addresses ...string) Option {
+	return func(s *Server) {
+		s.ips = map[string]*ipState{}
+		for _, address := range addresses {
+			s.addFreeIP(address)
+		}
+	}
+}
+
+// WithTransitionPolls sets the number of details calls for which servers and
+// databases stay in an intermediate state, such as locked while a server is
+// created, before the transition completes. Defaults to 0, which completes
+// the transition on the first details call.
+func WithTransitionPolls(polls int) Option {
+	return func(s *Server) {
+		s.transitionPolls = polls
+	}
+}
+
+// WithClock sets the function used to timestamp created objects. Defaults to
+// time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// Server is a fake GleSYS API. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	apiKey          string
+	now             func() time.Time
+	project         string
+	transitionPolls int
+
+	mu        sync.Mutex
+	calls     []string
+	faults    map[string][]*Fault
+	hooks     []Hook
+	latencies map[string]time.Duration
+	sequence  int
+
+	databases       map[string]*databaseState
+	domains         map[string]*glesys.DNSDomain
+	emailAccounts   map[string]*glesys.EmailAccount
+	emailAliases    map[string]*glesys.EmailAlias
+	ips             map[string]*ipState
+	loadBalancers   map[string]*glesys.LoadBalancerDetails
+	plans           []glesys.DatabasePlan
+	privateNetworks map[string]*privateNetworkState
+	records         map[int]*glesys.DNSDomainRecord
+	servers         map[string]*serverState
+}
+
+type handler func(s *Server, r *request) (map[string]interface{}, error)
+
+var handlers = map[string]handler{}
+
+// NewServer starts a new fake API. It should be closed when finished.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		now:             time.Now,
+		faults:          map[string][]*Fault{},
+		latencies:       map[string]time.Duration{},
+		databases:       map[string]*databaseState{},
+		domains:         map[string]*glesys.DNSDomain{},
+		emailAccounts:   map[string]*glesys.EmailAccount{},
+		emailAliases:    map[string]*glesys.EmailAlias{},
+		ips:             map[string]*ipState{},
+		loadBalancers:   map[string]*glesys.LoadBalancerDetails{},
+		plans:           defaultDatabasePlans(),
+		privateNetworks: map[string]*privateNetworkState{},
+		records:         map[int]*glesys.DNSDomainRecord{},
+		servers:         map[string]*serverState{},
+	}
+	for i := 10; i < 30; i++ {
+		s.addFreeIP(fmt.Sprintf("192.0.2.%d", i))
+		s.addFreeIP(fmt.Sprintf("2001:db8::%d", i))
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// NewClient creates a client for the fake API. It uses the credentials set
+// with WithCredentials, if any.
+func (s *Server) NewClient(opts ...glesys.Option) (*glesys.Client, error) {
+	project, apiKey := s.project, s.apiKey
+	if project == "" {
+		project, apiKey = "CL12345", "api-key"
+	}
+	return glesys.NewClientWithOptions(project, apiKey, append([]glesys.Option{glesys.WithBaseURL(s.URL)}, opts...)...)
+}
+
+// AddHook adds a hook which is called before every request is handled.
+func (s *Server) AddHook(hook Hook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hooks = append(s.hooks, hook)
+}
+
+// FailNext makes the next call to endpoint, for example "server/create",
+// fail with statusCode and text. Multiple failures are returned in order.
+func (s *Server) FailNext(endpoint string, statusCode int, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults[endpoint] = append(s.faults[endpoint], &Fault{StatusCode: statusCode, Text: text})
+}
+
+// SetLatency delays all calls to endpoint by d. An empty endpoint delays all
+// calls.
+func (s *Server) SetLatency(endpoint string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latencies[endpoint] = d
+}
+
+// Calls returns the endpoints which have been called, in order.
+func (s *Server) Calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.calls...)
+}
+
+// request is a call to the fake API. Parameters from the JSON body and from
+// the path, such as serverid in server/details/serverid/kvm12345, are merged.
+type request struct {
+	endpoint string
+	params   map[string]interface{}
+}
+
+// decode decodes the parameters of the request into v.
+func (r *request) decode(v interface{}) error {
+	data, err := json.Marshal(r.params)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return badRequest("Invalid parameters: %v", err)
+	}
+	return nil
+}
+
+// string returns the parameter key as a string.
+func (r *request) string(key string) string {
+	if value, ok := r.params[key]; ok && value != nil {
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+func parseRequest(httpRequest *http.Request) (*request, error) {
+	segments := strings.Split(strings.Trim(httpRequest.URL.Path, "/"), "/")
+	if len(segments) < 2 {
+		return nil, notFound("Unknown endpoint %s", httpRequest.URL.Path)
+	}
+
+	r := &request{
+		endpoint: strings.ToLower(segments[0] + "/" + segments[1]),
+		params:   map[string]interface{}{},
+	}
+
+	body, err := io.ReadAll(httpRequest.Body)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := strings.TrimSpace(string(body)); trimmed != "" && trimmed != "null" {
+		if err := json.Unmarshal(body, &r.params); err != nil {
+			return nil, badRequest("Invalid JSON body: %v", err)
+		}
+	}
+
+	for i := 2; i+1 < len(segments); i += 2 {
+		r.params[strings.ToLower(segments[i])] = segments[i+1]
+	}
+
+	return r, nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, httpRequest *http.Request) {
+	if s.apiKey != "" {
+		username, password, ok := httpRequest.BasicAuth()
+		if !ok || username != s.project || password != s.apiKey {
+			writeResponse(w, http.StatusUnauthorized, "Unauthorized", nil)
+			return
+		}
+	}
+
+	r, err := parseRequest(httpRequest)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, r.endpoint)
+	latency, ok := s.latencies[r.endpoint]
+	if !ok {
+		latency = s.latencies[""]
+	}
+	hooks := append([]Hook(nil), s.hooks...)
+	var fault *Fault
+	if faults := s.faults[r.endpoint]; len(faults) > 0 {
+		fault, s.faults[r.endpoint] = faults[0], faults[1:]
+	}
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-httpRequest.Context().Done():
+			return
+		}
+	}
+
+	for _, hook := range hooks {
+		if err := hook(r.endpoint, r.params); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	if fault != nil {
+		writeError(w, fault)
+		return
+	}
+
+	handle, ok := handlers[r.endpoint]
+	if !ok {
+		writeError(w, notFound("Unknown endpoint %s", r.endpoint))
+		return
+	}
+
+	// Handlers return the stored objects, so the response is encoded before
+	// the lock is released.
+	s.mu.Lock()
+	fields, err := handle(s, r)
+	var body []byte
+	if err == nil {
+		body, err = encodeResponse(http.StatusOK, "OK", fields)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var fault *Fault
+	if !errors.As(err, &fault) {
+		fault = &Fault{StatusCode: http.StatusInternalServerError, Text: err.Error()}
+	}
+	writeResponse(w, fault.StatusCode, fault.Text, nil)
+}
+
+func writeResponse(w http.ResponseWriter, statusCode int, text string, fields map[string]interface{}) {
+	body, _ := encodeResponse(statusCode, text, fields)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(body)
+}
+
+// encodeResponse encodes fields in the response envelope of the API.
+func encodeResponse(statusCode int, text string, fields map[string]interface{}) ([]byte, error) {
+	response := map[string]interface{}{
+		"status": map[string]interface{}{"code": statusCode, "text": text},
+	}
+	for key, value := range fields {
+		response[key] = value
+	}
+	return json.Marshal(map[string]interface{}{"response": response})
+}
+
+// nextID returns a new unique identifier with prefix.
+func (s *Server) nextID(prefix string) string {
+	s.sequence++
+	return fmt.Sprintf("%s%07d", prefix, 1000000+s.sequence)
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,104 @@
+package glesystest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	glesys "github.com/glesys/glesys-go/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, opts ...Option) (*Server, *glesys.Client) {
+	api := NewServer(opts...)
+	t.Cleanup(api.Close)
+
+	client, err := api.NewClient(glesys.WithRetryPolicy(glesys.RetryPolicy{MaxAttempts: 1}))
+	assert.NoError(t, err)
+	return api, client
+}
+
+func TestServerWithSetBaseURL(t *testing.T) {
+	api := NewServer()
+	defer api.Close()
+
+	client := glesys.NewClient("CL12345", "api-key", "test-application/0.0.1")
+	client.SetBaseURL(api.URL)
+
+	servers, err := client.Servers.List(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, *servers)
+	assert.Equal(t, []string{"server/list"}, api.Calls(), "call is recorded")
+}
+
+func TestServerCredentials(t *testing.T) {
+	api := NewServer(WithCredentials("CL12345", "secret"))
+	defer api.Close()
+
+	client, _ := api.NewClient()
+	_, err := client.Servers.List(context.Background())
+	assert.NoError(t, err, "configured credentials are accepted")
+
+	client = glesys.NewClient("CL12345", "wrong", "")
+	client.SetBaseURL(api.URL)
+	_, err = client.Servers.List(context.Background())
+	assert.ErrorIs(t, err, glesys.ErrUnauthorized)
+}
+
+func TestServerUnknownEndpoint(t *testing.T) {
+	_, client := newTestClient(t)
+
+	_, err := client.ObjectStorages.ListInstances(context.Background())
+	assert.ErrorIs(t, err, glesys.ErrNotFound)
+}
+
+func TestServerFailNext(t *testing.T) {
+	api, client := newTestClient(t)
+	api.FailNext("server/list", http.StatusServiceUnavailable, "Maintenance")
+
+	_, err := client.Servers.List(context.Background())
+	var apiError *glesys.APIError
+	assert.ErrorAs(t, err, &apiError)
+	assert.Equal(t, http.StatusServiceUnavailable, apiError.StatusCode, "injected status is returned")
+	assert.Equal(t, "Maintenance", apiError.Text, "injected text is returned")
+
+	_, err = client.Servers.List(context.Background())
+	assert.NoError(t, err, "fault is only returned once")
+}
+
+func TestServerHooks(t *testing.T) {
+	api, client := newTestClient(t)
+	api.AddHook(func(endpoint string, params map[string]interface{}) error {
+		if endpoint == "server/create" && params["hostname"] == "forbidden" {
+			return &Fault{StatusCode: http.StatusUnprocessableEntity, Text: "Hostname is not allowed"}
+		}
+		if endpoint == "server/destroy" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	_, err := client.Servers.Create(context.Background(), glesys.CreateServerParams{Hostname: "forbidden"}.WithDefaults())
+	assert.ErrorIs(t, err, glesys.ErrValidation, "fault from hook is returned")
+
+	err = client.Servers.Destroy(context.Background(), "kvm1", glesys.DestroyServerParams{})
+	var apiError *glesys.APIError
+	assert.ErrorAs(t, err, &apiError)
+	assert.Equal(t, http.StatusInternalServerError, apiError.StatusCode, "other errors are internal server errors")
+}
+
+func TestServerLatency(t *testing.T) {
+	api, client := newTestClient(t)
+	api.SetLatency("server/list", 50*time.Millisecond)
+
+	start := time.Now()
+	client.Servers.List(context.Background())
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond, "call is delayed")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.Servers.List(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "delayed call honours the context")
+}
@@ -0,0 +1,157 @@
+package glesystest
+
+import (
+	"strings"
+
+	glesys "github.com/glesys/glesys-go/v8"
+)
+
+// ipState is an IP address in the pool of the fake API. An address is free
+// when it is neither reserved nor assigned to a server or load balancer.
+type ipState struct {
+	ip       glesys.IP
+	reserved bool
+}
+
+func (ip *ipState) free() bool {
+	return !ip.reserved && ip.ip.ServerID == ""
+}
+
+func init() {
+	handlers["ip/details"] = (*Server).ipDetails
+	handlers["ip/listfree"] = (*Server).listFreeIPs
+	handlers["ip/listown"] = (*Server).listOwnIPs
+	handlers["ip/release"] = (*Server).releaseIP
+	handlers["ip/take"] = (*Server).takeIP
+}
+
+func (s *Server) addFreeIP(address string) {
+	version := 4
+	if strings.Contains(address, ":") {
+		version = 6
+	}
+	s.ips[address] = &ipState{ip: glesys.IP{
+		Address:    address,
+		DataCenter: "Falkenberg",
+		Platforms:  []string{"KVM", "VMware"},
+		Version:    version,
+	}}
+}
+
+// assignIP assigns an IP address to owner. The address "any" assigns the
+// first free address of version, while "none" and "" assign nothing.
+func (s *Server) assignIP(address string, version int, owner string) (*glesys.IP, error) {
+	switch strings.ToLower(address) {
+	case "", "none", "no":
+		return nil, nil
+	case "any":
+		for _, key := range sortedKeys(s.ips) {
+			if ip := s.ips[key]; ip.free() && ip.ip.Version == version {
+				ip.ip.ServerID = owner
+				return &ip.ip, nil
+			}
+		}
+		return nil, badRequest("No free IPv%d address available", version)
+	}
+
+	ip, ok := s.ips[address]
+	if !ok {
+		return nil, notFound("IP address %s does not exist", address)
+	}
+	if ip.ip.ServerID != "" {
+		return nil, badRequest("IP address %s is already in use", address)
+	}
+	ip.ip.ServerID = owner
+	return &ip.ip, nil
+}
+
+// unassignIPs removes all addresses from owner. Addresses are released unless
+// keep is set, in which case they stay reserved.
+func (s *Server) unassignIPs(owner string, keep bool) {
+	for _, ip := range s.ips {
+		if ip.ip.ServerID == owner {
+			ip.ip.ServerID = ""
+			ip.reserved = keep
+		}
+	}
+}
+
+func (s *Server) ip(address string) (*ipState, error) {
+	ip, ok := s.ips[address]
+	if !ok {
+		return nil, notFound("IP address %s does not exist", address)
+	}
+	return ip, nil
+}
+
+func (s *Server) ipDetails(r *request) (map[string]interface{}, error) {
+	ip, err := s.ip(r.string("ipaddress"))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"details": ip.ip}, nil
+}
+
+func (s *Server) listFreeIPs(r *request) (map[string]interface{}, error) {
+	var params glesys.AvailableIPsParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+
+	addresses := []string{}
+	for _, key := range sortedKeys(s.ips) {
+		ip := s.ips[key]
+		if ip.free() && (params.Version == 0 || ip.ip.Version == params.Version) {
+			addresses = append(addresses, ip.ip.Address)
+		}
+	}
+	return map[string]interface{}{"iplist": map[string]interface{}{"ipaddresses": addresses}}, nil
+}
+
+func (s *Server) listOwnIPs(r *request) (map[string]interface{}, error) {
+	var params glesys.ReservedIPsParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+
+	ips := []glesys.IP{}
+	for _, key := range sortedKeys(s.ips) {
+		ip := s.ips[key]
+		if ip.free() || (params.Version != 0 && ip.ip.Version != params.Version) {
+			continue
+		}
+		used := ip.ip.ServerID != ""
+		if (params.Used == "yes" && !used) || (params.Used == "no" && used) {
+			continue
+		}
+		ips = append(ips, ip.ip)
+	}
+	return map[string]interface{}{"iplist": ips}, nil
+}
+
+func (s *Server) releaseIP(r *request) (map[string]interface{}, error) {
+	ip, err := s.ip(r.string("ipaddress"))
+	if err != nil {
+		return nil, err
+	}
+	if ip.ip.ServerID != "" {
+		return nil, badRequest("IP address %s is in use by %s", ip.ip.Address, ip.ip.ServerID)
+	}
+	if !ip.reserved {
+		return nil, badRequest("IP address %s is not reserved", ip.ip.Address)
+	}
+	ip.reserved = false
+	return nil, nil
+}
+
+func (s *Server) takeIP(r *request) (map[string]interface{}, error) {
+	ip, err := s.ip(r.string("ipaddress"))
+	if err != nil {
+		return nil, err
+	}
+	if !ip.free() {
+		return nil, badRequest("IP address %s is not available", ip.ip.Address)
+	}
+	ip.reserved = true
+	return map[string]interface{}{"details": ip.ip}, nil
+}
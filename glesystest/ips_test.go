@@ -0,0 +1,57 @@
+package glesystest
+
+import (
+	"context"
+	"testing"
+
+	glesys "github.com/glesys/glesys-go/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPReserveAndRelease(t *testing.T) {
+	_, client := newTestClient(t, WithFreeIPs("192.0.2.1", "192.0.2.2", "2001:db8::1"))
+	ctx := context.Background()
+
+	available, err := client.IPs.Available(ctx, glesys.AvailableIPsParams{Version: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, []glesys.IP{{Address: "192.0.2.1"}, {Address: "192.0.2.2"}}, *available)
+
+	ip, err := client.IPs.Reserve(ctx, "192.0.2.1")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, ip.Version)
+	_, err = client.IPs.Reserve(ctx, "192.0.2.1")
+	assert.ErrorIs(t, err, glesys.ErrValidation, "reserved address can not be reserved again")
+
+	reserved, _ := client.IPs.Reserved(ctx, glesys.ReservedIPsParams{})
+	assert.Len(t, *reserved, 1)
+
+	assert.NoError(t, client.IPs.Release(ctx, "192.0.2.1"))
+	available, _ = client.IPs.Available(ctx, glesys.AvailableIPsParams{Version: 4})
+	assert.Len(t, *available, 2, "released address is available")
+}
+
+func TestIPAssignedToServer(t *testing.T) {
+	_, client := newTestClient(t, WithFreeIPs("192.0.2.1", "192.0.2.2"))
+	ctx := context.Background()
+
+	client.IPs.Reserve(ctx, "192.0.2.2")
+	server, err := client.Servers.Create(ctx, glesys.CreateServerParams{Hostname: "web-01", IPv4: "192.0.2.2", IPv6: "none"}.WithDefaults())
+	assert.NoError(t, err)
+	assert.Equal(t, []glesys.ServerIP{{Address: "192.0.2.2", Version: 4}}, server.IPList)
+
+	err = client.IPs.Release(ctx, "192.0.2.2")
+	assert.ErrorIs(t, err, glesys.ErrValidation, "address in use can not be released")
+
+	used, _ := client.IPs.Reserved(ctx, glesys.ReservedIPsParams{Used: "yes"})
+	assert.Equal(t, server.ID, (*used)[0].ServerID, "address is used by server")
+
+	client.Servers.Details(ctx, server.ID)
+	client.Servers.Destroy(ctx, server.ID, glesys.DestroyServerParams{KeepIP: true})
+	unused, _ := client.IPs.Reserved(ctx, glesys.ReservedIPsParams{Used: "no"})
+	assert.Equal(t, "192.0.2.2", (*unused)[0].Address, "address is kept")
+
+	_, err = client.Servers.Create(ctx, glesys.CreateServerParams{Hostname: "web-02", IPv6: "none"}.WithDefaults())
+	assert.NoError(t, err)
+	_, err = client.Servers.Create(ctx, glesys.CreateServerParams{Hostname: "web-03", IPv6: "none"}.WithDefaults())
+	assert.ErrorIs(t, err, glesys.ErrValidation, "pool is exhausted")
+}
@@ -0,0 +1,502 @@
+package glesystest
+
+import (
+	glesys "github.com/glesys/glesys-go/v8"
+)
+
+func init() {
+	handlers["loadbalancer/addbackend"] = (*Server).addBackend
+	handlers["loadbalancer/addfrontend"] = (*Server).addFrontend
+	handlers["loadbalancer/addtarget"] = (*Server).addTarget
+	handlers["loadbalancer/addtoblocklist"] = (*Server).addToBlocklist
+	handlers["loadbalancer/create"] = (*Server).createLoadBalancer
+	handlers["loadbalancer/destroy"] = (*Server).destroyLoadBalancer
+	handlers["loadbalancer/details"] = (*Server).loadBalancerDetails
+	handlers["loadbalancer/disabletarget"] = (*Server).disableTarget
+	handlers["loadbalancer/edit"] = (*Server).editLoadBalancer
+	handlers["loadbalancer/editbackend"] = (*Server).editBackend
+	handlers["loadbalancer/editfrontend"] = (*Server).editFrontend
+	handlers["loadbalancer/edittarget"] = (*Server).editTarget
+	handlers["loadbalancer/enabletarget"] = (*Server).enableTarget
+	handlers["loadbalancer/list"] = (*Server).listLoadBalancers
+	handlers["loadbalancer/removebackend"] = (*Server).removeBackend
+	handlers["loadbalancer/removefrontend"] = (*Server).removeFrontend
+	handlers["loadbalancer/removefromblocklist"] = (*Server).removeFromBlocklist
+	handlers["loadbalancer/removetarget"] = (*Server).removeTarget
+}
+
+func (s *Server) loadBalancer(r *request) (*glesys.LoadBalancerDetails, error) {
+	id := r.string("loadbalancerid")
+	loadBalancer, ok := s.loadBalancers[id]
+	if !ok {
+		return nil, notFound("Load balancer %s does not exist", id)
+	}
+	return loadBalancer, nil
+}
+
+func loadBalancerResponse(loadBalancer *glesys.LoadBalancerDetails) map[string]interface{} {
+	for i := range loadBalancer.BackendsList {
+		backend := &loadBalancer.BackendsList[i]
+		backend.Status = "DOWN"
+		for _, target := range backend.Targets {
+			if target.Enabled {
+				backend.Status = "UP"
+			}
+		}
+	}
+	return map[string]interface{}{"loadbalancer": loadBalancer}
+}
+
+func findBackend(loadBalancer *glesys.LoadBalancerDetails, name string) (*glesys.LoadBalancerBackend, error) {
+	for i := range loadBalancer.BackendsList {
+		if loadBalancer.BackendsList[i].Name == name {
+			return &loadBalancer.BackendsList[i], nil
+		}
+	}
+	return nil, notFound("Backend %s does not exist", name)
+}
+
+func findFrontend(loadBalancer *glesys.LoadBalancerDetails, name string) (*glesys.LoadBalancerFrontend, error) {
+	for i := range loadBalancer.FrontendsList {
+		if loadBalancer.FrontendsList[i].Name == name {
+			return &loadBalancer.FrontendsList[i], nil
+		}
+	}
+	return nil, notFound("Frontend %s does not exist", name)
+}
+
+func findTarget(backend *glesys.LoadBalancerBackend, name string) (*glesys.Target, error) {
+	for i := range backend.Targets {
+		if backend.Targets[i].Name == name {
+			return &backend.Targets[i], nil
+		}
+	}
+	return nil, notFound("Target %s does not exist", name)
+}
+
+func (s *Server) createLoadBalancer(r *request) (map[string]interface{}, error) {
+	var params glesys.CreateLoadBalancerParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	if params.Name == "" {
+		return nil, badRequest("name is required")
+	}
+
+	id := s.nextID("lb")
+	loadBalancer := &glesys.LoadBalancerDetails{
+		BackendsList:  []glesys.LoadBalancerBackend{},
+		Blocklists:    []string{},
+		DataCenter:    params.DataCenter,
+		FrontendsList: []glesys.LoadBalancerFrontend{},
+		ID:            id,
+		IPList:        []glesys.LoadBalancerIP{},
+		Name:          params.Name,
+	}
+
+	for _, assignment := range []struct {
+		address string
+		version int
+	}{{params.IPv4, 4}, {params.IPv6, 6}} {
+		ip, err := s.assignIP(assignment.address, assignment.version, id)
+		if err != nil {
+			s.unassignIPs(id, false)
+			return nil, err
+		}
+		if ip != nil {
+			loadBalancer.IPList = append(loadBalancer.IPList, glesys.LoadBalancerIP{Address: ip.Address, Version: ip.Version})
+		}
+	}
+
+	s.loadBalancers[id] = loadBalancer
+	return loadBalancerResponse(loadBalancer), nil
+}
+
+func (s *Server) destroyLoadBalancer(r *request) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	s.unassignIPs(loadBalancer.ID, false)
+	delete(s.loadBalancers, loadBalancer.ID)
+	return nil, nil
+}
+
+func (s *Server) loadBalancerDetails(r *request) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	return loadBalancerResponse(loadBalancer), nil
+}
+
+func (s *Server) editLoadBalancer(r *request) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.EditLoadBalancerParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	if params.Name != "" {
+		loadBalancer.Name = params.Name
+	}
+	return loadBalancerResponse(loadBalancer), nil
+}
+
+func (s *Server) listLoadBalancers(r *request) (map[string]interface{}, error) {
+	loadBalancers := []glesys.LoadBalancer{}
+	for _, id := range sortedKeys(s.loadBalancers) {
+		loadBalancer := s.loadBalancers[id]
+		loadBalancers = append(loadBalancers, glesys.LoadBalancer{
+			DataCenter: loadBalancer.DataCenter,
+			ID:         loadBalancer.ID,
+			Name:       loadBalancer.Name,
+		})
+	}
+	return map[string]interface{}{"loadbalancers": loadBalancers}, nil
+}
+
+func (s *Server) addBackend(r *request) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.AddBackendParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	if params.Name == "" {
+		return nil, badRequest("name is required")
+	}
+	if _, err := findBackend(loadBalancer, params.Name); err == nil {
+		return nil, badRequest("Backend %s already exists", params.Name)
+	}
+
+	mode := params.Mode
+	if mode == "" {
+		mode = "http"
+	}
+	stickySession := params.StickySession
+	if stickySession == "" {
+		stickySession = "no"
+	}
+	loadBalancer.BackendsList = append(loadBalancer.BackendsList, glesys.LoadBalancerBackend{
+		ConnectTimeout:  orDefault(params.ConnectTimeout, 4000),
+		Mode:            mode,
+		Name:            params.Name,
+		ResponseTimeout: orDefault(params.ResponseTimeout, 50000),
+		StickySession:   stickySession,
+		Targets:         []glesys.Target{},
+	})
+	return loadBalancerResponse(loadBalancer), nil
+}
+
+func (s *Server) editBackend(r *request) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.EditBackendParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	backend, err := findBackend(loadBalancer, params.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.ConnectTimeout != 0 {
+		backend.ConnectTimeout = params.ConnectTimeout
+	}
+	if params.Mode != "" {
+		backend.Mode = params.Mode
+	}
+	if params.ResponseTimeout != 0 {
+		backend.ResponseTimeout = params.ResponseTimeout
+	}
+	if params.StickySession != "" {
+		backend.StickySession = params.StickySession
+	}
+	return loadBalancerResponse(loadBalancer), nil
+}
+
+func (s *Server) removeBackend(r *request) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.RemoveBackendParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	if _, err := findBackend(loadBalancer, params.Name); err != nil {
+		return nil, err
+	}
+	for _, frontend := range loadBalancer.FrontendsList {
+		if frontend.Backend == params.Name {
+			return nil, badRequest("Backend %s is used by frontend %s", params.Name, frontend.Name)
+		}
+	}
+
+	backends := []glesys.LoadBalancerBackend{}
+	for _, backend := range loadBalancer.BackendsList {
+		if backend.Name != params.Name {
+			backends = append(backends, backend)
+		}
+	}
+	loadBalancer.BackendsList = backends
+	return nil, nil
+}
+
+func (s *Server) addFrontend(r *request) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.AddFrontendParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	if params.Name == "" || params.Port == 0 {
+		return nil, badRequest("name and port are required")
+	}
+	if _, err := findBackend(loadBalancer, params.Backend); err != nil {
+		return nil, err
+	}
+	if _, err := findFrontend(loadBalancer, params.Name); err == nil {
+		return nil, badRequest("Frontend %s already exists", params.Name)
+	}
+	for _, frontend := range loadBalancer.FrontendsList {
+		if frontend.Port == params.Port {
+			return nil, badRequest("Port %d is used by frontend %s", params.Port, frontend.Name)
+		}
+	}
+
+	loadBalancer.FrontendsList = append(loadBalancer.FrontendsList, glesys.LoadBalancerFrontend{
+		Backend:        params.Backend,
+		ClientTimeout:  orDefault(params.ClientTimeout, 50000),
+		MaxConnections: orDefault(params.MaxConnections, 2000),
+		Name:           params.Name,
+		Port:           params.Port,
+		Status:         "UP",
+		SSLCertificate: params.SSLCertificate,
+	})
+	return loadBalancerResponse(loadBalancer), nil
+}
+
+func (s *Server) editFrontend(r *request) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.EditFrontendParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	frontend, err := findFrontend(loadBalancer, params.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.ClientTimeout != 0 {
+		frontend.ClientTimeout = params.ClientTimeout
+	}
+	if params.MaxConnections != 0 {
+		frontend.MaxConnections = params.MaxConnections
+	}
+	if params.Port != 0 {
+		frontend.Port = params.Port
+	}
+	if params.SSLCertificate != "" {
+		frontend.SSLCertificate = params.SSLCertificate
+	}
+	return loadBalancerResponse(loadBalancer), nil
+}
+
+func (s *Server) removeFrontend(r *request) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.RemoveFrontendParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	if _, err := findFrontend(loadBalancer, params.Name); err != nil {
+		return nil, err
+	}
+
+	frontends := []glesys.LoadBalancerFrontend{}
+	for _, frontend := range loadBalancer.FrontendsList {
+		if frontend.Name != params.Name {
+			frontends = append(frontends, frontend)
+		}
+	}
+	loadBalancer.FrontendsList = frontends
+	return nil, nil
+}
+
+func (s *Server) addTarget(r *request) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.AddTargetParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	backend, err := findBackend(loadBalancer, params.Backend)
+	if err != nil {
+		return nil, err
+	}
+	if params.Name == "" || params.TargetIP == "" || params.Port == 0 {
+		return nil, badRequest("name, ipaddress and port are required")
+	}
+	if _, err := findTarget(backend, params.Name); err == nil {
+		return nil, badRequest("Target %s already exists", params.Name)
+	}
+
+	backend.Targets = append(backend.Targets, glesys.Target{
+		Enabled:  true,
+		Name:     params.Name,
+		Port:     params.Port,
+		Status:   "UP",
+		TargetIP: params.TargetIP,
+		Weight:   orDefault(params.Weight, 1),
+	})
+	return loadBalancerResponse(loadBalancer), nil
+}
+
+func (s *Server) editTarget(r *request) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.EditTargetParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	backend, err := findBackend(loadBalancer, params.Backend)
+	if err != nil {
+		return nil, err
+	}
+	target, err := findTarget(backend, params.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Port != 0 {
+		target.Port = params.Port
+	}
+	if params.TargetIP != "" {
+		target.TargetIP = params.TargetIP
+	}
+	if params.Weight != 0 {
+		target.Weight = params.Weight
+	}
+	return loadBalancerResponse(loadBalancer), nil
+}
+
+func (s *Server) toggleTarget(r *request, enabled bool) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.ToggleTargetParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	backend, err := findBackend(loadBalancer, params.Backend)
+	if err != nil {
+		return nil, err
+	}
+	target, err := findTarget(backend, params.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	target.Enabled = enabled
+	target.Status = "DISABLED"
+	if enabled {
+		target.Status = "UP"
+	}
+	return loadBalancerResponse(loadBalancer), nil
+}
+
+func (s *Server) enableTarget(r *request) (map[string]interface{}, error) {
+	return s.toggleTarget(r, true)
+}
+
+func (s *Server) disableTarget(r *request) (map[string]interface{}, error) {
+	return s.toggleTarget(r, false)
+}
+
+func (s *Server) removeTarget(r *request) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.RemoveTargetParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	backend, err := findBackend(loadBalancer, params.Backend)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := findTarget(backend, params.Name); err != nil {
+		return nil, err
+	}
+
+	targets := []glesys.Target{}
+	for _, target := range backend.Targets {
+		if target.Name != params.Name {
+			targets = append(targets, target)
+		}
+	}
+	backend.Targets = targets
+	return nil, nil
+}
+
+func (s *Server) addToBlocklist(r *request) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.BlocklistParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	for _, prefix := range loadBalancer.Blocklists {
+		if prefix == params.Prefix {
+			return nil, badRequest("Prefix %s is already blocked", params.Prefix)
+		}
+	}
+	loadBalancer.Blocklists = append(loadBalancer.Blocklists, params.Prefix)
+	return loadBalancerResponse(loadBalancer), nil
+}
+
+func (s *Server) removeFromBlocklist(r *request) (map[string]interface{}, error) {
+	loadBalancer, err := s.loadBalancer(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.BlocklistParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+
+	blocklist := []string{}
+	for _, prefix := range loadBalancer.Blocklists {
+		if prefix != params.Prefix {
+			blocklist = append(blocklist, prefix)
+		}
+	}
+	if len(blocklist) == len(loadBalancer.Blocklists) {
+		return nil, notFound("Prefix %s is not blocked", params.Prefix)
+	}
+	loadBalancer.Blocklists = blocklist
+	return loadBalancerResponse(loadBalancer), nil
+}
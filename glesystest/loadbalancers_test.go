@@ -0,0 +1,55 @@
+package glesystest
+
+import (
+	"context"
+	"testing"
+
+	glesys "github.com/glesys/glesys-go/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadBalancerBackendsFrontendsAndTargets(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	lb, err := client.LoadBalancers.Create(ctx, glesys.CreateLoadBalancerParams{Name: "web", DataCenter: "Falkenberg", IPv4: "any"})
+	assert.NoError(t, err)
+	assert.Len(t, lb.IPList, 1, "ip address is assigned")
+
+	_, err = client.LoadBalancers.AddFrontend(ctx, lb.ID, glesys.AddFrontendParams{Name: "http", Port: 80, Backend: "web"})
+	assert.ErrorIs(t, err, glesys.ErrNotFound, "backend has to exist")
+
+	client.LoadBalancers.AddBackend(ctx, lb.ID, glesys.AddBackendParams{Name: "web"})
+	lb, err = client.LoadBalancers.AddFrontend(ctx, lb.ID, glesys.AddFrontendParams{Name: "http", Port: 80, Backend: "web"})
+	assert.NoError(t, err)
+	assert.Equal(t, 80, lb.FrontendsList[0].Port)
+
+	lb, err = client.LoadBalancers.AddTarget(ctx, lb.ID, glesys.AddTargetParams{Backend: "web", Name: "web-01", Port: 8080, TargetIP: "192.0.2.100"})
+	assert.NoError(t, err)
+	assert.Equal(t, "UP", lb.BackendsList[0].Status, "backend with enabled target is up")
+
+	lb, _ = client.LoadBalancers.DisableTarget(ctx, lb.ID, glesys.ToggleTargetParams{Backend: "web", Name: "web-01"})
+	assert.False(t, lb.BackendsList[0].Targets[0].Enabled, "target is disabled")
+	assert.Equal(t, "DOWN", lb.BackendsList[0].Status, "backend without enabled targets is down")
+
+	lb, _ = client.LoadBalancers.EditTarget(ctx, lb.ID, glesys.EditTargetParams{Backend: "web", Name: "web-01", Weight: 5})
+	assert.Equal(t, 5, lb.BackendsList[0].Targets[0].Weight, "target is edited")
+
+	err = client.LoadBalancers.RemoveBackend(ctx, lb.ID, glesys.RemoveBackendParams{Name: "web"})
+	assert.ErrorIs(t, err, glesys.ErrValidation, "backend used by frontend can not be removed")
+
+	assert.NoError(t, client.LoadBalancers.RemoveTarget(ctx, lb.ID, glesys.RemoveTargetParams{Backend: "web", Name: "web-01"}))
+	assert.NoError(t, client.LoadBalancers.RemoveFrontend(ctx, lb.ID, glesys.RemoveFrontendParams{Name: "http"}))
+	assert.NoError(t, client.LoadBalancers.RemoveBackend(ctx, lb.ID, glesys.RemoveBackendParams{Name: "web"}))
+
+	lb, _ = client.LoadBalancers.AddToBlocklist(ctx, lb.ID, glesys.BlocklistParams{Prefix: "198.51.100.0/24"})
+	assert.Equal(t, []string{"198.51.100.0/24"}, lb.Blocklists)
+
+	lb, _ = client.LoadBalancers.Details(ctx, lb.ID)
+	assert.Empty(t, lb.BackendsList)
+	assert.Empty(t, lb.FrontendsList)
+
+	assert.NoError(t, client.LoadBalancers.Destroy(ctx, lb.ID))
+	loadBalancers, _ := client.LoadBalancers.List(ctx)
+	assert.Empty(t, *loadBalancers, "load balancer is destroyed")
+}
@@ -0,0 +1,168 @@
+package glesystest
+
+import (
+	"fmt"
+
+	glesys "github.com/glesys/glesys-go/v8"
+)
+
+type privateNetworkState struct {
+	network    glesys.PrivateNetwork
+	ipv6Prefix string
+	segments   map[string]*glesys.PrivateNetworkSegment
+}
+
+func init() {
+	handlers["privatenetwork/create"] = (*Server).createPrivateNetwork
+	handlers["privatenetwork/createsegment"] = (*Server).createSegment
+	handlers["privatenetwork/delete"] = (*Server).deletePrivateNetwork
+	handlers["privatenetwork/deletesegment"] = (*Server).deleteSegment
+	handlers["privatenetwork/details"] = (*Server).privateNetworkDetails
+	handlers["privatenetwork/edit"] = (*Server).editPrivateNetwork
+	handlers["privatenetwork/editsegment"] = (*Server).editSegment
+	handlers["privatenetwork/list"] = (*Server).listPrivateNetworks
+	handlers["privatenetwork/listsegments"] = (*Server).listSegments
+}
+
+func (s *Server) privateNetwork(r *request) (*privateNetworkState, error) {
+	id := r.string("privatenetworkid")
+	network, ok := s.privateNetworks[id]
+	if !ok {
+		return nil, notFound("Private network %s does not exist", id)
+	}
+	return network, nil
+}
+
+// segment returns the segment with id and the private network it belongs to.
+func (s *Server) segment(id string) (*privateNetworkState, *glesys.PrivateNetworkSegment, error) {
+	for _, network := range s.privateNetworks {
+		if segment, ok := network.segments[id]; ok {
+			return network, segment, nil
+		}
+	}
+	return nil, nil, notFound("Segment %s does not exist", id)
+}
+
+func (s *Server) createPrivateNetwork(r *request) (map[string]interface{}, error) {
+	name := r.string("name")
+	if name == "" {
+		return nil, badRequest("name is required")
+	}
+
+	id := s.nextID("pn-")
+	prefix := fmt.Sprintf("2001:db8:%x", s.sequence)
+	network := &privateNetworkState{
+		network: glesys.PrivateNetwork{
+			ID:            id,
+			IPv6Aggregate: prefix + "::/48",
+			Name:          name,
+		},
+		ipv6Prefix: prefix,
+		segments:   map[string]*glesys.PrivateNetworkSegment{},
+	}
+	s.privateNetworks[id] = network
+	return map[string]interface{}{"privatenetwork": network.network}, nil
+}
+
+func (s *Server) deletePrivateNetwork(r *request) (map[string]interface{}, error) {
+	network, err := s.privateNetwork(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(network.segments) > 0 {
+		return nil, badRequest("Private network %s has segments", network.network.ID)
+	}
+	delete(s.privateNetworks, network.network.ID)
+	return nil, nil
+}
+
+func (s *Server) privateNetworkDetails(r *request) (map[string]interface{}, error) {
+	network, err := s.privateNetwork(r)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"privatenetwork": network.network}, nil
+}
+
+func (s *Server) editPrivateNetwork(r *request) (map[string]interface{}, error) {
+	network, err := s.privateNetwork(r)
+	if err != nil {
+		return nil, err
+	}
+	if name := r.string("name"); name != "" {
+		network.network.Name = name
+	}
+	return map[string]interface{}{"privatenetwork": network.network}, nil
+}
+
+func (s *Server) listPrivateNetworks(r *request) (map[string]interface{}, error) {
+	networks := []glesys.PrivateNetwork{}
+	for _, id := range sortedKeys(s.privateNetworks) {
+		networks = append(networks, s.privateNetworks[id].network)
+	}
+	return map[string]interface{}{"privatenetworks": networks}, nil
+}
+
+func (s *Server) createSegment(r *request) (map[string]interface{}, error) {
+	network, err := s.privateNetwork(r)
+	if err != nil {
+		return nil, err
+	}
+	var params glesys.CreatePrivateNetworkSegmentParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	if params.Name == "" || params.IPv4Subnet == "" {
+		return nil, badRequest("name and ipv4subnet are required")
+	}
+	for _, segment := range network.segments {
+		if segment.IPv4Subnet == params.IPv4Subnet {
+			return nil, badRequest("Subnet %s is used by segment %s", params.IPv4Subnet, segment.ID)
+		}
+	}
+
+	id := s.nextID("seg-")
+	segment := &glesys.PrivateNetworkSegment{
+		ID:         id,
+		Name:       params.Name,
+		IPv4Subnet: params.IPv4Subnet,
+		IPv6Subnet: fmt.Sprintf("%s:%x::/64", network.ipv6Prefix, s.sequence),
+		Platform:   params.Platform,
+		Datacenter: params.Datacenter,
+	}
+	network.segments[id] = segment
+	return map[string]interface{}{"privatenetworksegment": segment}, nil
+}
+
+func (s *Server) deleteSegment(r *request) (map[string]interface{}, error) {
+	network, segment, err := s.segment(r.string("id"))
+	if err != nil {
+		return nil, err
+	}
+	delete(network.segments, segment.ID)
+	return nil, nil
+}
+
+func (s *Server) editSegment(r *request) (map[string]interface{}, error) {
+	_, segment, err := s.segment(r.string("id"))
+	if err != nil {
+		return nil, err
+	}
+	if name := r.string("name"); name != "" {
+		segment.Name = name
+	}
+	return map[string]interface{}{"privatenetworksegment": segment}, nil
+}
+
+func (s *Server) listSegments(r *request) (map[string]interface{}, error) {
+	network, err := s.privateNetwork(r)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := []glesys.PrivateNetworkSegment{}
+	for _, id := range sortedKeys(network.segments) {
+		segments = append(segments, *network.segments[id])
+	}
+	return map[string]interface{}{"privatenetworksegments": segments}, nil
+}
@@ -0,0 +1,42 @@
+package glesystest
+
+import (
+	"context"
+	"testing"
+
+	glesys "github.com/glesys/glesys-go/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivateNetworkSegments(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	network, err := client.PrivateNetworks.Create(ctx, "internal")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, network.IPv6Aggregate)
+
+	segment, err := client.PrivateNetworks.CreateSegment(ctx, glesys.CreatePrivateNetworkSegmentParams{
+		PrivateNetworkID: network.ID,
+		Datacenter:       "Falkenberg",
+		IPv4Subnet:       "10.0.0.0/24",
+		Name:             "web",
+		Platform:         "KVM",
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, segment.IPv6Subnet, "ipv6 subnet is assigned")
+
+	segment, _ = client.PrivateNetworks.EditSegment(ctx, glesys.EditPrivateNetworkSegmentParams{ID: segment.ID, Name: "frontend"})
+	assert.Equal(t, "frontend", segment.Name, "segment is edited")
+
+	segments, _ := client.PrivateNetworks.ListSegments(ctx, network.ID)
+	assert.Equal(t, []glesys.PrivateNetworkSegment{*segment}, *segments)
+
+	err = client.PrivateNetworks.Destroy(ctx, network.ID)
+	assert.ErrorIs(t, err, glesys.ErrValidation, "network with segments can not be deleted")
+
+	assert.NoError(t, client.PrivateNetworks.DestroySegment(ctx, segment.ID))
+	assert.NoError(t, client.PrivateNetworks.Destroy(ctx, network.ID))
+	networks, _ := client.PrivateNetworks.List(ctx)
+	assert.Empty(t, *networks, "network is deleted")
+}
@@ -0,0 +1,312 @@
+package glesystest
+
+import (
+	"strings"
+
+	glesys "github.com/glesys/glesys-go/v8"
+)
+
+// serverState is a server in the fake API. A pending transition is applied
+// after the configured number of details calls.
+type serverState struct {
+	details glesys.ServerDetails
+	pending *serverTransition
+}
+
+type serverTransition struct {
+	polls   int
+	running bool
+}
+
+func init() {
+	handlers["server/allowedarguments"] = (*Server).allowedArguments
+	handlers["server/clone"] = (*Server).cloneServer
+	handlers["server/create"] = (*Server).createServer
+	handlers["server/destroy"] = (*Server).destroyServer
+	handlers["server/details"] = (*Server).serverDetails
+	handlers["server/edit"] = (*Server).editServer
+	handlers["server/list"] = (*Server).listServers
+	handlers["server/start"] = (*Server).startServer
+	handlers["server/stop"] = (*Server).stopServer
+}
+
+// transition locks a server until it reaches the running or stopped state.
+func (s *Server) transition(server *serverState, running bool) {
+	server.details.IsLocked = true
+	server.details.State = "locked"
+	server.pending = &serverTransition{polls: s.transitionPolls, running: running}
+}
+
+// advance moves a server one step closer to the end of its transition.
+func (server *serverState) advance() {
+	if server.pending == nil {
+		return
+	}
+	if server.pending.polls > 0 {
+		server.pending.polls--
+		return
+	}
+
+	server.details.IsLocked = false
+	server.details.IsRunning = server.pending.running
+	server.details.State = "stopped"
+	if server.pending.running {
+		server.details.State = "running"
+	}
+	server.pending = nil
+}
+
+func (s *Server) server(r *request) (*serverState, error) {
+	id := r.string("serverid")
+	server, ok := s.servers[id]
+	if !ok {
+		return nil, notFound("Server %s does not exist", id)
+	}
+	return server, nil
+}
+
+func (s *Server) unlockedServer(r *request) (*serverState, error) {
+	server, err := s.server(r)
+	if err != nil {
+		return nil, err
+	}
+	if server.details.IsLocked {
+		return nil, locked("Server %s is locked", server.details.ID)
+	}
+	return server, nil
+}
+
+// allowedArgumentsList is the argument matrix returned by
+// server/allowedarguments. Templates are not listed and therefore all
+// allowed.
+var allowedArgumentsList = map[string]interface{}{
+	"KVM": map[string]interface{}{
+		"datacenter": map[string]interface{}{"list": []string{"Falkenberg", "Stockholm", "Oslo", "Amsterdam"}},
+		"cpucores":   map[string]interface{}{"unit": "cores", "list": []int{1, 2, 3, 4, 6, 8, 12, 16}},
+		"memorysize": map[string]interface{}{"unit": "MB", "list": []int{512, 1024, 2048, 4096, 8192, 16384, 32768}},
+		"disksize":   map[string]interface{}{"unit": "GB", "list": []int{5, 10, 20, 50, 100, 150, 200, 500}},
+		"bandwidth":  map[string]interface{}{"unit": "Mbit/s", "list": []int{100, 200, 500, 1000}},
+	},
+	"VMware": map[string]interface{}{
+		"datacenter": map[string]interface{}{"list": []string{"Falkenberg", "Stockholm"}},
+		"cpucores":   map[string]interface{}{"unit": "cores", "list": []int{1, 2, 4, 8}},
+		"memorysize": map[string]interface{}{"unit": "MB", "list": []int{1024, 2048, 4096, 8192}},
+		"disksize":   map[string]interface{}{"unit": "GB", "list": []int{20, 50, 100, 200}},
+		"bandwidth":  map[string]interface{}{"unit": "Mbit/s", "list": []int{100, 1000}},
+	},
+}
+
+func (s *Server) allowedArguments(r *request) (map[string]interface{}, error) {
+	return map[string]interface{}{"argumentslist": allowedArgumentsList}, nil
+}
+
+func (s *Server) createServer(r *request) (map[string]interface{}, error) {
+	var params glesys.CreateServerParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	if params.Hostname == "" {
+		return nil, badRequest("hostname is required")
+	}
+	if params.Template == "" {
+		return nil, badRequest("templatename is required")
+	}
+
+	prefix := "kvm"
+	if strings.EqualFold(params.Platform, "VMware") {
+		prefix = "wps"
+	}
+	id := s.nextID(prefix)
+
+	server := &serverState{details: glesys.ServerDetails{
+		Backup:          glesys.ServerBackupDetails{Enabled: "no", Schedules: params.Backup},
+		Bandwidth:       params.Bandwidth,
+		CPU:             params.CPU,
+		DataCenter:      params.DataCenter,
+		Description:     params.Description,
+		Hostname:        params.Hostname,
+		ID:              id,
+		InitialTemplate: glesys.ServerTemplateDetails{ID: params.Template, Name: params.Template},
+		IPList:          []glesys.ServerIP{},
+		Memory:          params.Memory,
+		Platform:        params.Platform,
+		Storage:         params.Storage,
+		Template:        params.Template,
+	}}
+	if len(params.Backup) > 0 {
+		server.details.Backup.Enabled = "yes"
+	}
+
+	for _, assignment := range []struct {
+		address string
+		version int
+	}{{params.IPv4, 4}, {params.IPv6, 6}} {
+		ip, err := s.assignIP(assignment.address, assignment.version, id)
+		if err != nil {
+			s.unassignIPs(id, false)
+			return nil, err
+		}
+		if ip != nil {
+			server.details.IPList = append(server.details.IPList, glesys.ServerIP{Address: ip.Address, Version: ip.Version})
+		}
+	}
+
+	s.transition(server, true)
+	s.servers[id] = server
+	return map[string]interface{}{"server": server.details}, nil
+}
+
+func (s *Server) cloneServer(r *request) (map[string]interface{}, error) {
+	source, err := s.unlockedServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var params glesys.CloneServerParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	if params.Hostname == "" {
+		return nil, badRequest("hostname is required")
+	}
+
+	details := source.details
+	id := s.nextID(details.ID[:3])
+	details.ID = id
+	details.Hostname = params.Hostname
+	details.Description = params.Description
+	details.IPList = []glesys.ServerIP{}
+	details.AdditionalDisks = nil
+	if params.DataCenter != "" {
+		details.DataCenter = params.DataCenter
+	}
+	if params.CPU != 0 {
+		details.CPU = params.CPU
+	}
+	if params.Memory != 0 {
+		details.Memory = params.Memory
+	}
+	if params.Storage != 0 {
+		details.Storage = params.Storage
+	}
+	if params.Bandwidth != 0 {
+		details.Bandwidth = params.Bandwidth
+	}
+
+	for _, version := range []int{4, 6} {
+		ip, err := s.assignIP("any", version, id)
+		if err != nil {
+			s.unassignIPs(id, false)
+			return nil, err
+		}
+		if ip != nil {
+			details.IPList = append(details.IPList, glesys.ServerIP{Address: ip.Address, Version: ip.Version})
+		}
+	}
+
+	server := &serverState{details: details}
+	s.transition(server, true)
+	s.servers[id] = server
+	return map[string]interface{}{"server": server.details}, nil
+}
+
+func (s *Server) destroyServer(r *request) (map[string]interface{}, error) {
+	server, err := s.unlockedServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var params glesys.DestroyServerParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+
+	s.unassignIPs(server.details.ID, params.KeepIP)
+	delete(s.servers, server.details.ID)
+	return nil, nil
+}
+
+func (s *Server) serverDetails(r *request) (map[string]interface{}, error) {
+	server, err := s.server(r)
+	if err != nil {
+		return nil, err
+	}
+	server.advance()
+	return map[string]interface{}{"server": server.details}, nil
+}
+
+func (s *Server) editServer(r *request) (map[string]interface{}, error) {
+	server, err := s.unlockedServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var params glesys.EditServerParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+
+	details := &server.details
+	if params.Backup != nil {
+		details.Backup = glesys.ServerBackupDetails{Enabled: "yes", Schedules: params.Backup}
+	}
+	if params.Bandwidth != 0 {
+		details.Bandwidth = params.Bandwidth
+	}
+	if params.CPU != 0 {
+		details.CPU = params.CPU
+	}
+	if params.Description != "" {
+		details.Description = params.Description
+	}
+	if params.Hostname != "" {
+		details.Hostname = params.Hostname
+	}
+	if params.Memory != 0 {
+		details.Memory = params.Memory
+	}
+	if params.Storage != 0 {
+		if params.Storage < details.Storage {
+			return nil, badRequest("disksize can not be decreased")
+		}
+		details.Storage = params.Storage
+	}
+	return map[string]interface{}{"server": server.details}, nil
+}
+
+func (s *Server) listServers(r *request) (map[string]interface{}, error) {
+	servers := []glesys.Server{}
+	for _, id := range sortedKeys(s.servers) {
+		details := s.servers[id].details
+		servers = append(servers, glesys.Server{
+			DataCenter: details.DataCenter,
+			Hostname:   details.Hostname,
+			ID:         details.ID,
+			Platform:   details.Platform,
+		})
+	}
+	return map[string]interface{}{"servers": servers}, nil
+}
+
+func (s *Server) startServer(r *request) (map[string]interface{}, error) {
+	server, err := s.unlockedServer(r)
+	if err != nil {
+		return nil, err
+	}
+	s.transition(server, true)
+	return nil, nil
+}
+
+func (s *Server) stopServer(r *request) (map[string]interface{}, error) {
+	server, err := s.unlockedServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var params glesys.StopServerParams
+	if err := r.decode(&params); err != nil {
+		return nil, err
+	}
+	s.transition(server, params.Type == "reboot")
+	return nil, nil
+}
@@ -0,0 +1,113 @@
+package glesystest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	glesys "github.com/glesys/glesys-go/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerLifecycle(t *testing.T) {
+	_, client := newTestClient(t, WithTransitionPolls(1))
+	ctx := context.Background()
+
+	server, err := client.Servers.Create(ctx, glesys.CreateServerParams{Hostname: "web-01"}.WithDefaults())
+	assert.NoError(t, err)
+	assert.Equal(t, "kvm", server.ID[:3], "id is prefixed with the platform")
+	assert.True(t, server.IsLocked, "new server is locked")
+	assert.Len(t, server.IPList, 2, "ip addresses are assigned")
+
+	err = client.Servers.Stop(ctx, server.ID, glesys.StopServerParams{Type: "soft"})
+	assert.ErrorIs(t, err, glesys.ErrLocked, "locked server can not be stopped")
+
+	details, _ := client.Servers.Details(ctx, server.ID)
+	assert.Equal(t, "locked", details.State, "server is locked during transition")
+	details, _ = client.Servers.Details(ctx, server.ID)
+	assert.Equal(t, "running", details.State, "server is running after transition")
+	assert.True(t, details.IsRunning)
+
+	assert.NoError(t, client.Servers.Stop(ctx, server.ID, glesys.StopServerParams{Type: "soft"}))
+	client.Servers.Details(ctx, server.ID)
+	details, _ = client.Servers.Details(ctx, server.ID)
+	assert.Equal(t, "stopped", details.State, "server is stopped")
+	assert.False(t, details.IsRunning)
+
+	details, err = client.Servers.Edit(ctx, server.ID, glesys.EditServerParams{CPU: 4, Description: "web"})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, details.CPU, "cpu is edited")
+	assert.Equal(t, 2048, details.Memory, "memory is unchanged")
+
+	servers, _ := client.Servers.List(ctx)
+	assert.Equal(t, []glesys.Server{{DataCenter: "Falkenberg", Hostname: "web-01", ID: server.ID, Platform: "KVM"}}, *servers)
+
+	assert.NoError(t, client.Servers.Destroy(ctx, server.ID, glesys.DestroyServerParams{}))
+	_, err = client.Servers.Details(ctx, server.ID)
+	assert.ErrorIs(t, err, glesys.ErrNotFound, "server is destroyed")
+}
+
+func TestServerWaiters(t *testing.T) {
+	_, client := newTestClient(t, WithTransitionPolls(3))
+	ctx := context.Background()
+	poll := glesys.WithPollInterval(time.Millisecond, time.Millisecond)
+
+	server, _ := client.Servers.Create(ctx, glesys.CreateServerParams{Hostname: "web-01"}.WithDefaults())
+	details, err := glesys.WaitUntilServerRunning(ctx, client.Servers, server.ID, poll)
+	assert.NoError(t, err)
+	assert.Equal(t, "running", details.State, "waiter returns when the server is running")
+
+	client.Servers.Stop(ctx, server.ID, glesys.StopServerParams{Type: "soft"})
+	details, err = glesys.WaitUntilServerStopped(ctx, client.Servers, server.ID, poll)
+	assert.NoError(t, err)
+	assert.Equal(t, "stopped", details.State, "waiter returns when the server is stopped")
+}
+
+func TestServerCreateRequiresHostname(t *testing.T) {
+	_, client := newTestClient(t)
+
+	_, err := client.Servers.Create(context.Background(), glesys.CreateServerParams{Template: "Debian 12"})
+	assert.ErrorIs(t, err, glesys.ErrValidation)
+}
+
+func TestServerParamsValidation(t *testing.T) {
+	api := NewServer()
+	t.Cleanup(api.Close)
+	client, err := api.NewClient(glesys.WithParamsValidation(0))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.Servers.Create(ctx, glesys.CreateServerParams{Hostname: "web-01", CPU: 5}.WithDefaults())
+	var paramsErr *glesys.ParamsError
+	assert.ErrorAs(t, err, &paramsErr)
+	_, ok := paramsErr.Field("CPU")
+	assert.True(t, ok, "cpu is invalid")
+	assert.Equal(t, []string{"server/allowedarguments"}, api.Calls(), "invalid server is not created")
+
+	server, err := client.Servers.Create(ctx, glesys.CreateServerParams{Hostname: "web-01"}.WithDefaults())
+	assert.NoError(t, err)
+
+	_, err = client.Servers.Edit(ctx, server.ID, glesys.EditServerParams{Memory: 3000})
+	assert.ErrorIs(t, err, glesys.ErrValidation)
+	assert.Equal(t, []string{"server/allowedarguments", "server/create", "server/details"}, api.Calls(), "allowed arguments are cached")
+}
+
+func TestServerClone(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	server, _ := client.Servers.Create(ctx, glesys.CreateServerParams{Hostname: "web-03", Description: "web"}.WithDefaults())
+	client.Servers.Details(ctx, server.ID)
+
+	clone, err := client.Servers.Clone(ctx, server.ID, glesys.CloneServerParams{Hostname: "web-04", DataCenter: "Stockholm", CPU: 4})
+	assert.NoError(t, err)
+	assert.NotEqual(t, server.ID, clone.ID, "clone is a new server")
+	assert.Equal(t, "web-04", clone.Hostname)
+	assert.Equal(t, "Stockholm", clone.DataCenter, "data center is overridden")
+	assert.Equal(t, 4, clone.CPU, "cpu is overridden")
+	assert.Equal(t, server.Memory, clone.Memory, "memory is copied")
+	assert.Equal(t, server.Template, clone.Template, "template is copied")
+	assert.Empty(t, clone.Description)
+	assert.Len(t, clone.IPList, 2, "new ip addresses are assigned")
+	assert.NotEqual(t, server.IPList, clone.IPList)
+}
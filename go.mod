@@ -3,8 +3,7 @@ module github.com/glesys/glesys-go/v8
 require (
 	dario.cat/mergo v1.0.2
 	github.com/stretchr/testify v1.12.1
+	go.yaml.in/yaml/v3 v3.0.5
 )
 
-require go.yaml.in/yaml/v3 v3.0.5 // indirect
-
-go 1.18
+go 1.21
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratedMocksAreUpToDate(t *testing.T) {
+	services, used, err := parseServices("../../..")
+	assert.NoError(t, err)
+
+	code, err := generate(services, used)
+	assert.NoError(t, err)
+
+	existing, err := os.ReadFile("../../../glesysmock/mocks.go")
+	assert.NoError(t, err)
+	assert.Equal(t, string(existing), string(code), "glesysmock/mocks.go is outdated, run go generate ./glesysmock")
+}
+
+func TestZero(t *testing.T) {
+	assert.Equal(t, "nil", zero("error"))
+	assert.Equal(t, "new(glesys.ServerDetails)", zero("*glesys.ServerDetails"))
+	assert.Equal(t, `""`, zero("string"))
+	assert.Equal(t, "*new(glesys.Server)", zero("glesys.Server"))
+}
@@ -0,0 +1,267 @@
+// Package mustache renders mustache templates as specified by
+// https://github.com/mustache/spec, without lambdas. Partials are given as
+// text and inserted as is, they are not rendered.
+package mustache
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type kind byte
+
+const (
+	text     kind = 't'
+	variable kind = 'v'
+	raw      kind = '&'
+	section  kind = '#'
+	inverted kind = '^'
+)
+
+type node struct {
+	kind     kind
+	value    string
+	children []*node
+}
+
+// Render renders template with data. The data is converted to JSON types
+// first, so names refer to the JSON keys of structs. Variables are HTML
+// escaped unless rendered using triple mustaches or &. Partials are looked up
+// by name in partials, an error is returned for unknown partials.
+func Render(template string, data interface{}, partials map[string]string) (string, error) {
+	nodes, err := parse(template, partials)
+	if err != nil {
+		return "", err
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	var context interface{}
+	if err := json.Unmarshal(encoded, &context); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	render(&b, nodes, []interface{}{context})
+	return b.String(), nil
+}
+
+func parse(template string, partials map[string]string) ([]*node, error) {
+	root := &node{kind: section}
+	stack := []*node{root}
+	open, close := "{{", "}}"
+
+	pos := 0
+	for pos < len(template) {
+		current := stack[len(stack)-1]
+
+		i := strings.Index(template[pos:], open)
+		if i < 0 {
+			current.children = append(current.children, &node{kind: text, value: template[pos:]})
+			break
+		}
+		start := pos + i
+		after := start + len(open)
+
+		// Find the end of the tag, triple mustaches and delimiter changes
+		// have their own closing sequence.
+		closing := close
+		if after < len(template) {
+			switch {
+			case template[after] == '{' && open == "{{":
+				closing = "}" + close
+			case template[after] == '=':
+				closing = "=" + close
+			}
+		}
+		j := strings.Index(template[after:], closing)
+		if j < 0 {
+			return nil, fmt.Errorf("mustache: unclosed tag at offset %d", start)
+		}
+		end := after + j + len(closing)
+		tag := template[after : after+j]
+
+		sigil := byte(0)
+		if len(tag) > 0 {
+			sigil = tag[0]
+		}
+		name := strings.TrimSpace(tag)
+		switch sigil {
+		case '#', '^', '/', '!', '&', '>', '=', '{':
+			name = strings.TrimSpace(tag[1:])
+		}
+
+		// Standalone tags are removed together with their line.
+		lineStart := strings.LastIndex(template[:start], "\n") + 1
+		lineEnd := strings.Index(template[end:], "\n")
+		if lineEnd < 0 {
+			lineEnd = len(template)
+		} else {
+			lineEnd += end + 1
+		}
+		textEnd, next := start, end
+		standalone := false
+		switch sigil {
+		case '#', '^', '/', '!', '>', '=':
+			if lineStart >= pos && blank(template[lineStart:start]) && blank(template[end:lineEnd]) {
+				textEnd, next = lineStart, lineEnd
+				standalone = true
+			}
+		}
+		if textEnd > pos {
+			current.children = append(current.children, &node{kind: text, value: template[pos:textEnd]})
+		}
+		pos = next
+
+		switch sigil {
+		case '!':
+		case '>':
+			partial, ok := partials[name]
+			if !ok {
+				return nil, fmt.Errorf("mustache: unknown partial %q", name)
+			}
+			// Standalone partials are indented like the tag.
+			if standalone {
+				partial = indent(partial, template[lineStart:start])
+			}
+			current.children = append(current.children, &node{kind: text, value: partial})
+		case '=':
+			delimiters := strings.Fields(strings.TrimSuffix(name, "="))
+			if len(delimiters) != 2 {
+				return nil, fmt.Errorf("mustache: invalid delimiters %q", name)
+			}
+			open, close = delimiters[0], delimiters[1]
+		case '#', '^':
+			n := &node{kind: kind(sigil), value: name}
+			current.children = append(current.children, n)
+			stack = append(stack, n)
+		case '/':
+			if len(stack) == 1 || current.value != name {
+				return nil, fmt.Errorf("mustache: unexpected closing tag %q", name)
+			}
+			stack = stack[:len(stack)-1]
+		case '&', '{':
+			current.children = append(current.children, &node{kind: raw, value: name})
+		default:
+			current.children = append(current.children, &node{kind: variable, value: name})
+		}
+	}
+
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("mustache: unclosed section %q", stack[len(stack)-1].value)
+	}
+	return root.children, nil
+}
+
+// indent prefixes every line of s with prefix.
+func indent(s, prefix string) string {
+	if prefix == "" || s == "" {
+		return s
+	}
+	lines := strings.SplitAfter(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+func blank(s string) bool {
+	return strings.TrimLeft(s, " \t\r\n") == ""
+}
+
+func render(b *strings.Builder, nodes []*node, stack []interface{}) {
+	for _, n := range nodes {
+		switch n.kind {
+		case text:
+			b.WriteString(n.value)
+		case variable:
+			b.WriteString(escape(format(lookup(stack, n.value))))
+		case raw:
+			b.WriteString(format(lookup(stack, n.value)))
+		case section:
+			value := lookup(stack, n.value)
+			if !truthy(value) {
+				continue
+			}
+			if list, ok := value.([]interface{}); ok {
+				for _, item := range list {
+					render(b, n.children, append(stack, item))
+				}
+				continue
+			}
+			render(b, n.children, append(stack, value))
+		case inverted:
+			if !truthy(lookup(stack, n.value)) {
+				render(b, n.children, stack)
+			}
+		}
+	}
+}
+
+// lookup resolves a possibly dotted name. The first part is searched for in
+// the context stack from the top, the rest only in the found value.
+func lookup(stack []interface{}, name string) interface{} {
+	if name == "." {
+		return stack[len(stack)-1]
+	}
+
+	parts := strings.Split(name, ".")
+	var value interface{}
+	found := false
+	for i := len(stack) - 1; i >= 0 && !found; i-- {
+		if m, ok := stack[i].(map[string]interface{}); ok {
+			value, found = m[parts[0]]
+		}
+	}
+	for _, part := range parts[1:] {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[part]
+	}
+	return value
+}
+
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case float64:
+		return v != 0
+	case []interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+func format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
+
+var escaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
@@ -0,0 +1,85 @@
+package mustache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	data := map[string]interface{}{
+		"name":    "World",
+		"html":    `<b>"A & B"</b>`,
+		"count":   3,
+		"enabled": true,
+		"empty":   []string{},
+		"none":    nil,
+		"person":  map[string]interface{}{"name": "Alice", "address": map[string]interface{}{"city": "Falkenberg"}},
+		"list":    []map[string]interface{}{{"item": "a"}, {"item": "b"}},
+		"strings": []string{"x", "y"},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"variable", "Hello {{name}}!", "Hello World!"},
+		{"escaped", "{{html}}", "&lt;b&gt;&quot;A &amp; B&quot;&lt;/b&gt;"},
+		{"triple mustache", "{{{html}}}", `<b>"A & B"</b>`},
+		{"ampersand", "{{& html}}", `<b>"A & B"</b>`},
+		{"number", "{{count}}", "3"},
+		{"missing", "[{{missing}}]", "[]"},
+		{"dotted", "{{person.address.city}}", "Falkenberg"},
+		{"dotted missing", "[{{person.missing.city}}]", "[]"},
+		{"section list", "{{#list}}{{item}},{{/list}}", "a,b,"},
+		{"implicit iterator", "{{#strings}}({{.}}){{/strings}}", "(x)(y)"},
+		{"section map", "{{#person}}{{name}} in {{address.city}}{{/person}}", "Alice in Falkenberg"},
+		{"section context lookup", "{{#list}}{{name}}{{/list}}", "WorldWorld"},
+		{"section truthy", "{{#enabled}}on{{/enabled}}", "on"},
+		{"section falsy", "{{#none}}on{{/none}}{{#empty}}on{{/empty}}", ""},
+		{"inverted", "{{^empty}}none{{/empty}}{{^enabled}}off{{/enabled}}", "none"},
+		{"comment", "a{{! ignored }}b", "ab"},
+		{"standalone", "a\n  {{#enabled}}\nb\n  {{/enabled}}\nc\n", "a\nb\nc\n"},
+		{"standalone comment", "a\n{{! comment }}\nb", "a\nb"},
+		{"not standalone", "  {{#enabled}}b{{/enabled}}\n", "  b\n"},
+		{"indented list", "users:\n{{#list}}\n  - {{item}}\n{{/list}}\n", "users:\n  - a\n  - b\n"},
+		{"delimiters", "{{=<% %>=}}<% name %> {{name}}", "World {{name}}"},
+	}
+
+	for _, test := range tests {
+		out, err := Render(test.template, data, nil)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, out, test.name)
+	}
+}
+
+func TestRenderPartials(t *testing.T) {
+	partials := map[string]string{"users": "users:\n  - {{name}}\n"}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"inline", "a {{>users}}b", "a users:\n  - {{name}}\nb"},
+		{"standalone", "a\n{{>users}}\nb\n", "a\nusers:\n  - {{name}}\nb\n"},
+		{"standalone indented", "a\n  {{> users }}\nb\n", "a\n  users:\n    - {{name}}\nb\n"},
+	}
+
+	for _, test := range tests {
+		out, err := Render(test.template, map[string]interface{}{"name": "World"}, partials)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, out, test.name)
+	}
+
+	_, err := Render("{{>missing}}", nil, partials)
+	assert.EqualError(t, err, `mustache: unknown partial "missing"`)
+}
+
+func TestRenderErrors(t *testing.T) {
+	for _, template := range []string{"{{name", "{{#a}}", "{{/a}}", "{{#a}}{{/b}}", "{{=<%=}}"} {
+		_, err := Render(template, nil, nil)
+		assert.Error(t, err, template)
+	}
+}
@@ -0,0 +1,63 @@
+// Package redact removes secrets such as passwords and API keys from JSON
+// bodies before they are logged or stored.
+package redact
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Placeholder replaces redacted values.
+const Placeholder = "[REDACTED]"
+
+// keys are the JSON keys whose values are redacted.
+var keys = map[string]bool{
+	"apikey":           true,
+	"authcode":         true,
+	"connectionstring": true,
+	"otp":              true,
+	"password":         true,
+	"rootpassword":     true,
+	"secretkey":        true,
+}
+
+// IsSecret returns true if the values of key are redacted.
+func IsSecret(key string) bool {
+	return keys[strings.ToLower(key)]
+}
+
+// JSON returns body with all secrets redacted. The second return value is
+// false if body is not valid JSON, in which case body is returned unchanged.
+func JSON(body []byte) ([]byte, bool) {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body, false
+	}
+
+	out, err := json.Marshal(Value(v))
+	if err != nil {
+		return body, false
+	}
+	return out, true
+}
+
+// Value redacts the values of secret keys in a decoded JSON value.
+func Value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if IsSecret(key) {
+				if value != nil && value != "" {
+					v[key] = Placeholder
+				}
+				continue
+			}
+			v[key] = Value(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = Value(value)
+		}
+	}
+	return v
+}
@@ -0,0 +1,111 @@
+// Package shacrypt hashes passwords using the SHA-512 based crypt scheme,
+// see https://www.akkadia.org/drepper/SHA-crypt.txt, which is used for the
+// passwords of users in cloud-config.
+package shacrypt
+
+import (
+	"crypto/sha512"
+	"strings"
+)
+
+const rounds = 5000
+
+// maxSaltLength is the length salts are truncated to.
+const maxSaltLength = 16
+
+const alphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// order is the order in which the bytes of the digest are encoded, in groups
+// of three.
+var order = [][3]int{
+	{0, 21, 42}, {22, 43, 1}, {44, 2, 23}, {3, 24, 45}, {25, 46, 4}, {47, 5, 26}, {6, 27, 48},
+	{28, 49, 7}, {50, 8, 29}, {9, 30, 51}, {31, 52, 10}, {53, 11, 32}, {12, 33, 54}, {34, 55, 13},
+	{56, 14, 35}, {15, 36, 57}, {37, 58, 16}, {59, 17, 38}, {18, 39, 60}, {40, 61, 19}, {62, 20, 41},
+}
+
+// SHA512 returns the hash of password with salt in the format
+// $6$salt$hash, using the default number of rounds.
+func SHA512(password, salt string) string {
+	if len(salt) > maxSaltLength {
+		salt = salt[:maxSaltLength]
+	}
+	p, s := []byte(password), []byte(salt)
+
+	b := sha512.New()
+	b.Write(p)
+	b.Write(s)
+	b.Write(p)
+	digestB := b.Sum(nil)
+
+	a := sha512.New()
+	a.Write(p)
+	a.Write(s)
+	a.Write(repeat(digestB, len(p)))
+	for n := len(p); n > 0; n >>= 1 {
+		if n&1 == 1 {
+			a.Write(digestB)
+		} else {
+			a.Write(p)
+		}
+	}
+	digest := a.Sum(nil)
+
+	dp := sha512.New()
+	for range p {
+		dp.Write(p)
+	}
+	pBytes := repeat(dp.Sum(nil), len(p))
+
+	ds := sha512.New()
+	for i := 0; i < 16+int(digest[0]); i++ {
+		ds.Write(s)
+	}
+	sBytes := repeat(ds.Sum(nil), len(s))
+
+	for i := 0; i < rounds; i++ {
+		c := sha512.New()
+		if i%2 == 1 {
+			c.Write(pBytes)
+		} else {
+			c.Write(digest)
+		}
+		if i%3 != 0 {
+			c.Write(sBytes)
+		}
+		if i%7 != 0 {
+			c.Write(pBytes)
+		}
+		if i%2 == 1 {
+			c.Write(digest)
+		} else {
+			c.Write(pBytes)
+		}
+		digest = c.Sum(nil)
+	}
+
+	var out strings.Builder
+	out.WriteString("$6$")
+	out.WriteString(salt)
+	out.WriteString("$")
+	for _, group := range order {
+		encode(&out, uint(digest[group[0]])<<16|uint(digest[group[1]])<<8|uint(digest[group[2]]), 4)
+	}
+	encode(&out, uint(digest[63]), 2)
+	return out.String()
+}
+
+// repeat returns digest repeated to length n.
+func repeat(digest []byte, n int) []byte {
+	out := make([]byte, 0, n)
+	for len(out) < n {
+		out = append(out, digest[:min(len(digest), n-len(out))]...)
+	}
+	return out
+}
+
+func encode(out *strings.Builder, value uint, n int) {
+	for i := 0; i < n; i++ {
+		out.WriteByte(alphabet[value&0x3f])
+		value >>= 6
+	}
+}
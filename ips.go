@@ -6,6 +6,19 @@ import (
 	"strings"
 )
 
+// IPAPI is the interface implemented by IPService.
+type IPAPI interface {
+	Available(ctx context.Context, params AvailableIPsParams) (*[]IP, error)
+	Details(ctx context.Context, ipAddress string) (*IP, error)
+	Release(ctx context.Context, ipAddress string) error
+	Reserve(ctx context.Context, ipAddress string) (*IP, error)
+	Reserved(ctx context.Context, params ReservedIPsParams) (*[]IP, error)
+	SetPTR(ctx context.Context, ipAddress string, ptrdata string) (*IP, error)
+	ResetPTR(ctx context.Context, ipAddress string) (*IP, error)
+}
+
+var _ IPAPI = (*IPService)(nil)
+
 // IPService provides functions to interact with IP addresses
 type IPService struct {
 	client clientInterface
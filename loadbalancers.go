@@ -5,6 +5,33 @@ import (
 	"fmt"
 )
 
+// LoadBalancerAPI is the interface implemented by LoadBalancerService.
+type LoadBalancerAPI interface {
+	Create(ctx context.Context, params CreateLoadBalancerParams) (*LoadBalancerDetails, error)
+	Destroy(ctx context.Context, loadbalancerID string) error
+	Details(ctx context.Context, loadbalancerID string) (*LoadBalancerDetails, error)
+	Edit(ctx context.Context, loadbalancerID string, params EditLoadBalancerParams) (*LoadBalancerDetails, error)
+	List(ctx context.Context) (*[]LoadBalancer, error)
+	AddBackend(ctx context.Context, loadbalancerID string, params AddBackendParams) (*LoadBalancerDetails, error)
+	EditBackend(ctx context.Context, loadbalancerID string, params EditBackendParams) (*LoadBalancerDetails, error)
+	RemoveBackend(ctx context.Context, loadbalancerID string, params RemoveBackendParams) error
+	AddFrontend(ctx context.Context, loadbalancerID string, params AddFrontendParams) (*LoadBalancerDetails, error)
+	EditFrontend(ctx context.Context, loadbalancerID string, params EditFrontendParams) (*LoadBalancerDetails, error)
+	RemoveFrontend(ctx context.Context, loadbalancerID string, params RemoveFrontendParams) error
+	AddCertificate(ctx context.Context, loadbalancerID string, params AddCertificateParams) error
+	ListCertificates(ctx context.Context, loadbalancerID string) (*[]string, error)
+	RemoveCertificate(ctx context.Context, loadbalancerID string, params string) error
+	AddTarget(ctx context.Context, loadbalancerID string, params AddTargetParams) (*LoadBalancerDetails, error)
+	EditTarget(ctx context.Context, loadbalancerID string, params EditTargetParams) (*LoadBalancerDetails, error)
+	EnableTarget(ctx context.Context, loadbalancerID string, params ToggleTargetParams) (*LoadBalancerDetails, error)
+	DisableTarget(ctx context.Context, loadbalancerID string, params ToggleTargetParams) (*LoadBalancerDetails, error)
+	RemoveTarget(ctx context.Context, loadbalancerID string, params RemoveTargetParams) error
+	AddToBlocklist(ctx context.Context, loadbalancerID string, params BlocklistParams) (*LoadBalancerDetails, error)
+	RemoveFromBlocklist(ctx context.Context, loadbalancerID string, params BlocklistParams) (*LoadBalancerDetails, error)
+}
+
+var _ LoadBalancerAPI = (*LoadBalancerService)(nil)
+
 // LoadBalancerService provides functions to interact with LoadBalancers
 type LoadBalancerService struct {
 	client clientInterface
@@ -0,0 +1,122 @@
+package glesys
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/glesys/glesys-go/v8/internal/redact"
+)
+
+// LogOptions configures how API calls are logged, see WithLogOptions.
+type LogOptions struct {
+	// Level is the level successful calls are logged at. Defaults to
+	// slog.LevelDebug.
+	Level slog.Leveler
+	// ErrorLevel is the level failed calls are logged at. Defaults to
+	// slog.LevelError.
+	ErrorLevel slog.Leveler
+	// LogBodies enables logging of request and response bodies. Secrets such
+	// as passwords, API keys and connection strings are redacted. The
+	// Authorization header is never logged.
+	LogBodies bool
+}
+
+// WithLogOptions configures how API calls are logged by the logger set with
+// WithLogger.
+func WithLogOptions(logOptions LogOptions) Option {
+	return func(o *options) error {
+		o.logOptions = logOptions
+		return nil
+	}
+}
+
+// logCall logs the outcome of an API call, if a logger is configured.
+func logCall(logger *slog.Logger, logOptions LogOptions, request *Request, response *Response, err error, duration time.Duration) {
+	if logger == nil {
+		return
+	}
+
+	ctx := request.HTTPRequest.Context()
+	level := slog.LevelDebug
+	if logOptions.Level != nil {
+		level = logOptions.Level.Level()
+	}
+	message := "glesys request"
+	if err != nil {
+		level = slog.LevelError
+		if logOptions.ErrorLevel != nil {
+			level = logOptions.ErrorLevel.Level()
+		}
+		message = "glesys request failed"
+	}
+
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", request.Method),
+		slog.String("path", request.Path),
+		slog.Duration("duration", duration),
+	}
+
+	if response != nil {
+		if response.HTTPResponse != nil {
+			attrs = append(attrs, slog.Int("status", response.HTTPResponse.StatusCode))
+		}
+		if text := statusText(response.Body); text != "" {
+			attrs = append(attrs, slog.String("status_text", text))
+		}
+		if response.Attempts > 1 {
+			attrs = append(attrs, slog.Int("attempts", response.Attempts))
+		}
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+
+	if logOptions.LogBodies {
+		if request.Params != nil {
+			if body, err := json.Marshal(request.Params); err == nil {
+				attrs = append(attrs, slog.String("request_body", redactBody(body)))
+			}
+		}
+		if response != nil && len(response.Body) > 0 {
+			attrs = append(attrs, slog.String("response_body", redactBody(response.Body)))
+		}
+	}
+
+	logger.LogAttrs(ctx, level, message, attrs...)
+}
+
+// statusText returns `response.status.text` of a response body.
+func statusText(body []byte) string {
+	data := struct {
+		Response struct {
+			Status struct {
+				Text string `json:"text"`
+			} `json:"status"`
+		} `json:"response"`
+	}{}
+	if json.Unmarshal(body, &data) != nil {
+		return ""
+	}
+	return strings.TrimSpace(data.Response.Status.Text)
+}
+
+// redactBody returns body with all secrets redacted. Bodies which are not
+// JSON are truncated.
+func redactBody(body []byte) string {
+	out, ok := redact.JSON(body)
+	if !ok {
+		s := strings.TrimSpace(string(body))
+		if len(s) > maxErrorBodySize {
+			s = s[:maxErrorBodySize]
+		}
+		return s
+	}
+	return string(out)
+}
@@ -1,28 +1,43 @@
 package glesys
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"log/slog"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // Login is used for login data
 type Login struct {
-	BaseURL    *url.URL
-	UserAgent  string
-	httpClient httpClientInterface
-	Accounts   []Customer
-	Customers  []Customer
-	APIKey     string
-	Username   string
+	BaseURL     *url.URL
+	UserAgent   string
+	httpClient  httpClientInterface
+	logger      *slog.Logger
+	logOptions  LogOptions
+	metrics     MetricsRecorder
+	middleware  []Middleware
+	rateLimiter *RateLimiter
+	retryPolicy *RetryPolicy
+	Accounts    []Customer
+	Customers   []Customer
+	APIKey      string
+	Username    string
 
 	Users *UserService
 }
 
+// UserAPI is the interface implemented by UserService.
+type UserAPI interface {
+	DoOTPLogin(ctx context.Context, username, password, otp string) (*LoginDetailsResponse, error)
+	LoginWithTOTPSecret(ctx context.Context, username, password, secret string) (*LoginDetailsResponse, error)
+	ListOrganizations(ctx context.Context) (*[]UserOrganization, error)
+	ListCustomerProjects(ctx context.Context, organizationNumber string) (*[]CustomerProject, error)
+}
+
+var _ UserAPI = (*UserService)(nil)
+
 type UserService struct {
 	client clientInterface
 }
@@ -100,66 +115,29 @@ type UserOrganization struct {
 	SLAPhonenumber                    string   `json:"slaphonenumber,omitempty"`
 }
 
-func (l *Login) do(request *http.Request, v interface{}) error {
-	response, err := l.httpClient.Do(request)
-	if err != nil {
-		return err
+// pipeline returns the request pipeline for the current configuration.
+func (l *Login) pipeline() *pipeline {
+	return &pipeline{
+		baseURL:     l.BaseURL,
+		httpClient:  l.httpClient,
+		logger:      l.logger,
+		logOptions:  l.logOptions,
+		metrics:     l.metrics,
+		middleware:  l.middleware,
+		rateLimiter: l.rateLimiter,
+		password:    l.APIKey,
+		retryPolicy: l.retryPolicy,
+		userAgent:   l.UserAgent,
+		username:    l.Username,
 	}
-
-	if response.StatusCode != http.StatusOK {
-		return handleResponseError(response)
-	}
-
-	return parseResponseBody(response, v)
 }
 
 func (l *Login) get(ctx context.Context, path string, v interface{}) error {
-	request, err := l.newRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return err
-	}
-	return l.do(request, v)
+	return l.pipeline().call(ctx, "GET", path, v, nil)
 }
 
 func (l *Login) post(ctx context.Context, path string, v interface{}, params interface{}) error {
-	request, err := l.newRequest(ctx, "POST", path, params)
-	if err != nil {
-		return err
-	}
-	return l.do(request, v)
-}
-
-func (l *Login) newRequest(ctx context.Context, method, path string, params interface{}) (*http.Request, error) {
-	u, err := url.Parse(path)
-	if err != nil {
-		return nil, err
-	}
-
-	if l.BaseURL != nil {
-		u = l.BaseURL.ResolveReference(u)
-	}
-
-	buffer := new(bytes.Buffer)
-
-	if params != nil {
-		err = json.NewEncoder(buffer).Encode(params)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	request, err := http.NewRequestWithContext(ctx, method, u.String(), buffer)
-	if err != nil {
-		return nil, err
-	}
-
-	userAgent := strings.TrimSpace(fmt.Sprintf("%s glesys-go/%s", l.UserAgent, version))
-
-	request.Header.Set("Content-Type", "application/json")
-	request.Header.Set("User-Agent", userAgent)
-	request.SetBasicAuth(l.Username, l.APIKey)
-
-	return request, nil
+	return l.pipeline().call(ctx, "POST", path, v, params)
 }
 
 // SetBaseURL can be used to set a custom BaseURL
@@ -173,17 +151,32 @@ func (l *Login) SetBaseURL(bu string) error {
 }
 
 func NewLogin(useragent string) *Login {
-	BaseURL, _ := url.Parse("https://api.glesys.com")
+	l, _ := NewLoginWithOptions(WithUserAgent(strings.TrimSpace(fmt.Sprintf("%s glesys-go/%s", useragent, version))))
+	return l
+}
+
+// NewLoginWithOptions creates a new Login configured by opts.
+func NewLoginWithOptions(opts ...Option) (*Login, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
 
 	l := &Login{
-		BaseURL:    BaseURL,
-		httpClient: http.DefaultClient,
-		UserAgent:  strings.TrimSpace(fmt.Sprintf("%s glesys-go/%s", useragent, version)),
+		BaseURL:     o.baseURL,
+		httpClient:  o.buildHTTPClient(),
+		logger:      o.logger,
+		logOptions:  o.logOptions,
+		metrics:     o.metrics,
+		middleware:  o.middleware,
+		rateLimiter: o.rateLimiter,
+		retryPolicy: o.retryPolicy,
+		UserAgent:   o.userAgent,
 	}
 
 	l.Users = &UserService{client: l}
 
-	return l
+	return l, nil
 }
 
 func (l *UserService) DoOTPLogin(ctx context.Context, username, password, otp string) (*LoginDetailsResponse, error) {
@@ -203,6 +196,16 @@ func (l *UserService) DoOTPLogin(ctx context.Context, username, password, otp st
 	return &data.Response.Login, err
 }
 
+// LoginWithTOTPSecret logs in using a one-time password generated from the
+// base32 encoded TOTP secret of the user, see NewTOTP.
+func (l *UserService) LoginWithTOTPSecret(ctx context.Context, username, password, secret string) (*LoginDetailsResponse, error) {
+	totp, err := NewTOTP(secret)
+	if err != nil {
+		return nil, err
+	}
+	return l.DoOTPLogin(ctx, username, password, totp.Code(time.Now()))
+}
+
 func (l *UserService) ListOrganizations(ctx context.Context) (*[]UserOrganization, error) {
 	data := struct {
 		Response struct {
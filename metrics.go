@@ -0,0 +1,61 @@
+package glesys
+
+import "time"
+
+// MetricsRecorder receives metrics about API calls, see WithMetrics.
+// Implementations must be safe for concurrent use. A Prometheus
+// implementation is available in the glesysprom module.
+type MetricsRecorder interface {
+	// RecordCall is called once for every API call when it has completed.
+	RecordCall(call CallMetrics)
+	// RecordRateLimitWait is called when a request had to wait for the rate
+	// limiter before it was sent.
+	RecordRateLimitWait(endpoint string, wait time.Duration)
+}
+
+// CallMetrics describes a completed API call.
+type CallMetrics struct {
+	// Method is the HTTP method of the call.
+	Method string
+	// Endpoint is the module and function of the call, e.g. `server/details`.
+	Endpoint string
+	// StatusCode is the HTTP status code of the response, or 0 if no response
+	// was received.
+	StatusCode int
+	// Duration is the time the call took, including retries.
+	Duration time.Duration
+	// Attempts is the number of requests sent, including retries.
+	Attempts int
+	// Err is the error returned by the call, if any.
+	Err error
+}
+
+// WithMetrics reports metrics about every API call to recorder.
+func WithMetrics(recorder MetricsRecorder) Option {
+	return func(o *options) error {
+		o.metrics = recorder
+		return nil
+	}
+}
+
+// recordCall reports a completed call, if a recorder is configured.
+func recordCall(recorder MetricsRecorder, request *Request, response *Response, err error, duration time.Duration) {
+	if recorder == nil {
+		return
+	}
+
+	call := CallMetrics{
+		Method:   request.Method,
+		Endpoint: request.Endpoint(),
+		Duration: duration,
+		Err:      err,
+	}
+	if response != nil {
+		call.Attempts = response.Attempts
+		if response.HTTPResponse != nil {
+			call.StatusCode = response.HTTPResponse.StatusCode
+		}
+	}
+
+	recorder.RecordCall(call)
+}
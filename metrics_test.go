@@ -0,0 +1,71 @@
+package glesys
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockMetricsRecorder struct {
+	mu    sync.Mutex
+	calls []CallMetrics
+	waits map[string]int
+}
+
+func (r *mockMetricsRecorder) RecordCall(call CallMetrics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func (r *mockMetricsRecorder) RecordRateLimitWait(endpoint string, wait time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.waits == nil {
+		r.waits = map[string]int{}
+	}
+	r.waits[endpoint]++
+}
+
+func TestMetricsRecordCall(t *testing.T) {
+	recorder := &mockMetricsRecorder{}
+	client, _ := NewClientWithOptions("project-id", "api-key", WithMetrics(recorder))
+	client.httpClient = &mockHTTPClient{body: `{ "response": {} }`, statusCode: 200}
+
+	client.Servers.Details(context.Background(), "kvm12345")
+
+	client.httpClient = &mockHTTPClient{body: `{ "response": { "status": { "code": 404 } } }`, statusCode: 404}
+	client.Servers.Details(context.Background(), "kvm12345")
+
+	assert.Len(t, recorder.calls, 2)
+	assert.Equal(t, "GET", recorder.calls[0].Method, "method is correct")
+	assert.Equal(t, "server/details", recorder.calls[0].Endpoint, "endpoint is correct")
+	assert.Equal(t, 200, recorder.calls[0].StatusCode, "status code is correct")
+	assert.Equal(t, 1, recorder.calls[0].Attempts, "attempts are correct")
+	assert.NoError(t, recorder.calls[0].Err)
+	assert.Equal(t, 404, recorder.calls[1].StatusCode, "status code is correct")
+	assert.ErrorIs(t, recorder.calls[1].Err, ErrNotFound)
+}
+
+func TestMetricsRecordRetriesAndRateLimitWaits(t *testing.T) {
+	recorder := &mockMetricsRecorder{}
+	client, _ := NewClientWithOptions("project-id", "api-key",
+		WithMetrics(recorder),
+		WithRetryPolicy(testRetryPolicy()),
+		WithRateLimiter(NewRateLimiter(RateLimit{ReadRate: 20, ReadBurst: 1})),
+	)
+	client.httpClient = &sequenceHTTPClient{responses: []*http.Response{
+		newTestResponse(503, `{}`, nil),
+		newTestResponse(200, `{}`, nil),
+	}}
+
+	client.IPs.Reserved(context.Background(), ReservedIPsParams{})
+
+	assert.Len(t, recorder.calls, 1)
+	assert.Equal(t, 2, recorder.calls[0].Attempts, "retry is recorded")
+	assert.Equal(t, 1, recorder.waits["ip/listown"], "rate limit wait is recorded")
+}
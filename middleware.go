@@ -0,0 +1,54 @@
+package glesys
+
+import "net/http"
+
+// Request is an API call passing through the middleware chain.
+type Request struct {
+	// Method is the HTTP method of the call.
+	Method string
+	// Path is the API path of the call, e.g. `server/create`.
+	Path string
+	// Params are the parameters of the call before they were encoded, or nil.
+	Params interface{}
+	// Project is the project the call is made for. It is empty for calls
+	// made by Login.
+	Project string
+	// HTTPRequest is the request which will be sent to the API. Middleware may
+	// modify it or replace it, e.g. to add headers or change its context.
+	HTTPRequest *http.Request
+}
+
+// Endpoint returns the module and function of the call, e.g.
+// `server/details` for the path `server/details/serverid/kvm123456`.
+func (r *Request) Endpoint() string {
+	return endpoint(r.Path)
+}
+
+// Response is the result of an API call passing through the middleware chain.
+type Response struct {
+	// HTTPResponse is the response from the API, or nil if no response was
+	// received. Its body has already been read, see Body.
+	HTTPResponse *http.Response
+	// Body is the body of the response.
+	Body []byte
+	// Attempts is the number of requests sent, including retries.
+	Attempts int
+}
+
+// Handler performs an API call. The error is an *APIError if the API
+// responded with an error.
+type Handler func(request *Request) (*Response, error)
+
+// Middleware wraps a Handler to add behaviour to every API call, such as
+// tracing, metrics or fault injection. A middleware may return without
+// calling next, e.g. to inject a failure.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds middleware to the chain every API call passes through.
+// The first middleware is the outermost one.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *options) error {
+		o.middleware = append(o.middleware, middleware...)
+		return nil
+	}
+}
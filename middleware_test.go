@@ -0,0 +1,123 @@
+package glesys
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareSeesRequestAndResponse(t *testing.T) {
+	mockClient := &mockHTTPClient{body: `{ "response": { "server": { "serverid": "kvm12345" } } }`, statusCode: 200}
+
+	var seen *Request
+	var seenResponse *Response
+	var seenErr error
+	client, _ := NewClientWithOptions("project-id", "api-key", WithMiddleware(func(next Handler) Handler {
+		return func(request *Request) (*Response, error) {
+			seen = request
+			seenResponse, seenErr = next(request)
+			return seenResponse, seenErr
+		}
+	}))
+	client.httpClient = mockClient
+
+	params := CreateServerParams{Hostname: "web-01"}
+	server, err := client.Servers.Create(context.Background(), params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "kvm12345", server.ID, "server ID is correct")
+	assert.Equal(t, "POST", seen.Method, "method is correct")
+	assert.Equal(t, "server/create", seen.Path, "path is correct")
+	assert.Equal(t, params, seen.Params, "params are correct")
+	assert.Equal(t, "project-id", seen.Project, "project is correct")
+	assert.Equal(t, mockClient.lastRequest, seen.HTTPRequest, "http request is correct")
+	assert.Equal(t, 200, seenResponse.HTTPResponse.StatusCode, "http response is correct")
+	assert.Equal(t, 1, seenResponse.Attempts, "attempts are correct")
+	assert.NoError(t, seenErr)
+}
+
+func TestMiddlewareSeesAPIError(t *testing.T) {
+	var seenErr error
+	client, _ := NewClientWithOptions("project-id", "api-key", WithMiddleware(func(next Handler) Handler {
+		return func(request *Request) (*Response, error) {
+			response, err := next(request)
+			seenErr = err
+			return response, err
+		}
+	}))
+	client.httpClient = &mockHTTPClient{body: `{ "response": { "status": { "code": 404, "text": "Not found" } } }`, statusCode: 404}
+
+	_, err := client.Servers.Details(context.Background(), "kvm12345")
+
+	assert.Equal(t, err, seenErr, "error is passed through the middleware")
+	assert.ErrorIs(t, seenErr, ErrNotFound)
+}
+
+func TestMiddlewareOrderAndHeaders(t *testing.T) {
+	mockClient := &mockHTTPClient{body: `{}`, statusCode: 200}
+
+	var order []string
+	middleware := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(request *Request) (*Response, error) {
+				order = append(order, name)
+				request.HTTPRequest.Header.Add("X-Middleware", name)
+				return next(request)
+			}
+		}
+	}
+
+	client, _ := NewClientWithOptions("project-id", "api-key", WithMiddleware(middleware("first"), middleware("second")))
+	client.httpClient = mockClient
+
+	client.Servers.List(context.Background())
+
+	assert.Equal(t, []string{"first", "second"}, order, "middleware is applied in order")
+	assert.Equal(t, []string{"first", "second"}, mockClient.lastRequest.Header.Values("X-Middleware"), "headers are added")
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	mockClient := &mockHTTPClient{body: `{}`, statusCode: 200}
+	injected := errors.New("injected failure")
+
+	client, _ := NewClientWithOptions("project-id", "api-key", WithMiddleware(func(next Handler) Handler {
+		return func(request *Request) (*Response, error) {
+			return nil, injected
+		}
+	}))
+	client.httpClient = mockClient
+
+	err := client.Servers.Start(context.Background(), "kvm12345")
+
+	assert.Equal(t, injected, err, "injected error is returned")
+	assert.Nil(t, mockClient.lastRequest, "no request was sent")
+}
+
+func TestMiddlewareIsSharedWithLogin(t *testing.T) {
+	mockClient := &mockHTTPClient{body: `{ "response": { "login": { "username": "alice@example.com" } } }`, statusCode: 200}
+
+	var paths []string
+	login, _ := NewLoginWithOptions(WithMiddleware(func(next Handler) Handler {
+		return func(request *Request) (*Response, error) {
+			paths = append(paths, request.Path)
+			return next(request)
+		}
+	}))
+	login.httpClient = mockClient
+
+	login.Users.DoOTPLogin(context.Background(), "alice@example.com", "password", "otp")
+
+	assert.Equal(t, []string{"user/login"}, paths, "middleware is called for Login")
+	assert.Equal(t, http.MethodPost, mockClient.lastRequest.Method, "request was sent")
+}
+
+func TestRequestEndpoint(t *testing.T) {
+	request := Request{Path: "server/details/serverid/kvm123456/includestate/yes"}
+	assert.Equal(t, "server/details", request.Endpoint(), "endpoint is correct")
+
+	request = Request{Path: "server/create"}
+	assert.Equal(t, "server/create", request.Endpoint(), "endpoint is correct")
+}
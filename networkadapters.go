@@ -5,6 +5,16 @@ import (
 	"fmt"
 )
 
+// NetworkAdapterAPI is the interface implemented by NetworkAdapterService.
+type NetworkAdapterAPI interface {
+	Create(ctx context.Context, params CreateNetworkAdapterParams) (*NetworkAdapter, error)
+	Details(ctx context.Context, networkAdapterID string) (*NetworkAdapter, error)
+	Destroy(ctx context.Context, networkAdapterID string) error
+	Edit(ctx context.Context, networkAdapterID string, params EditNetworkAdapterParams) (*NetworkAdapter, error)
+}
+
+var _ NetworkAdapterAPI = (*NetworkAdapterService)(nil)
+
 // NetworkAdapterService provides functions to interact with Networks
 type NetworkAdapterService struct {
 	client clientInterface
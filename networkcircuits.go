@@ -4,6 +4,14 @@ import (
 	"context"
 )
 
+// NetworkCircuitAPI is the interface implemented by NetworkCircuitService.
+type NetworkCircuitAPI interface {
+	Details(ctx context.Context, circuitID string) (*NetworkCircuit, error)
+	List(ctx context.Context) (*[]NetworkCircuit, error)
+}
+
+var _ NetworkCircuitAPI = (*NetworkCircuitService)(nil)
+
 // NetworkCircuitService provides functions to interact with NetworkCircuits
 type NetworkCircuitService struct {
 	client clientInterface
@@ -5,6 +5,17 @@ import (
 	"fmt"
 )
 
+// NetworkAPI is the interface implemented by NetworkService.
+type NetworkAPI interface {
+	Create(ctx context.Context, params CreateNetworkParams) (*Network, error)
+	Details(ctx context.Context, networkID string) (*Network, error)
+	Destroy(ctx context.Context, networkID string) error
+	Edit(ctx context.Context, networkID string, params EditNetworkParams) (*Network, error)
+	List(ctx context.Context) (*[]Network, error)
+}
+
+var _ NetworkAPI = (*NetworkService)(nil)
+
 // NetworkService provides functions to interact with Networks
 type NetworkService struct {
 	client clientInterface
@@ -4,6 +4,19 @@ import (
 	"context"
 )
 
+// ObjectStorageAPI is the interface implemented by ObjectStorageService.
+type ObjectStorageAPI interface {
+	CreateInstance(ctx context.Context, params CreateObjectStorageInstanceParams) (*ObjectStorageInstance, error)
+	InstanceDetails(ctx context.Context, instanceID string) (*ObjectStorageInstance, error)
+	DeleteInstance(ctx context.Context, instanceID string) error
+	EditInstance(ctx context.Context, params EditObjectStorageInstanceParams) (*ObjectStorageInstance, error)
+	ListInstances(ctx context.Context) (*[]ObjectStorageInstance, error)
+	CreateCredential(ctx context.Context, params CreateObjectStorageCredentialParams) (*ObjectStorageCredential, error)
+	DeleteCredential(ctx context.Context, params DeleteObjectStorageCredentialParams) error
+}
+
+var _ ObjectStorageAPI = (*ObjectStorageService)(nil)
+
 // ObjectStorageService provides functions to interact with Networks
 type ObjectStorageService struct {
 	client clientInterface
@@ -0,0 +1,144 @@
+package glesys
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures a Client created by NewClientWithOptions or a Login
+// created by NewLoginWithOptions.
+type Option func(*options) error
+
+// TransportMiddleware wraps the http.RoundTripper used to send requests.
+type TransportMiddleware func(http.RoundTripper) http.RoundTripper
+
+type options struct {
+	baseURL             *url.URL
+	httpClient          *http.Client
+	logger              *slog.Logger
+	logOptions          LogOptions
+	metrics             MetricsRecorder
+	middleware          []Middleware
+	paramsValidation    time.Duration
+	rateLimiter         *RateLimiter
+	retryPolicy         *RetryPolicy
+	timeout             time.Duration
+	transportMiddleware []TransportMiddleware
+	userAgent           string
+}
+
+// WithHTTPClient sets the http.Client used to send requests. The client is
+// copied and never modified. Defaults to http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) error {
+		if httpClient == nil {
+			return errors.New("glesys: http client must not be nil")
+		}
+		o.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithBaseURL sets the URL of the GleSYS API. Defaults to
+// https://api.glesys.com.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		o.baseURL = u
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of each HTTP request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) error {
+		if timeout < 0 {
+			return errors.New("glesys: timeout must not be negative")
+		}
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithUserAgent sets a user agent identifying your application, e.g.
+// "my-application/0.0.1".
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) error {
+		o.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithLogger sets the logger used to log requests to the API.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) error {
+		o.logger = logger
+		return nil
+	}
+}
+
+// WithRetryPolicy enables automatic retries of failed requests according to
+// policy. See DefaultRetryPolicy for sensible defaults.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) error {
+		o.retryPolicy = &policy
+		return nil
+	}
+}
+
+// WithTransportMiddleware wraps the transport of the http.Client. The first
+// middleware is the outermost one.
+func WithTransportMiddleware(middleware ...TransportMiddleware) Option {
+	return func(o *options) error {
+		o.transportMiddleware = append(o.transportMiddleware, middleware...)
+		return nil
+	}
+}
+
+func newOptions(opts []Option) (*options, error) {
+	baseURL, _ := url.Parse("https://api.glesys.com")
+	o := &options{baseURL: baseURL}
+
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// buildHTTPClient returns the http.Client to use, applying the timeout and
+// transport middleware to a copy of the configured client.
+func (o *options) buildHTTPClient() *http.Client {
+	if o.httpClient == nil && o.timeout == 0 && len(o.transportMiddleware) == 0 {
+		return http.DefaultClient
+	}
+
+	httpClient := &http.Client{}
+	if o.httpClient != nil {
+		*httpClient = *o.httpClient
+	}
+
+	if o.timeout > 0 {
+		httpClient.Timeout = o.timeout
+	}
+
+	if len(o.transportMiddleware) > 0 {
+		transport := httpClient.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		for i := len(o.transportMiddleware) - 1; i >= 0; i-- {
+			transport = o.transportMiddleware[i](transport)
+		}
+		httpClient.Transport = transport
+	}
+
+	return httpClient
+}
@@ -0,0 +1,118 @@
+package glesys
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func TestNewClientWithOptionsDefaults(t *testing.T) {
+	client, err := NewClientWithOptions("project-id", "api-key")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.glesys.com", client.BaseURL.String(), "BaseURL has correct default value")
+	assert.Equal(t, http.DefaultClient, client.httpClient, "http.DefaultClient is used by default")
+	assert.Nil(t, client.retryPolicy, "retries are disabled by default")
+	assert.NotNil(t, client.Servers, "services are set up")
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	httpClient := &http.Client{}
+	client, err := NewClientWithOptions("project-id", "api-key",
+		WithBaseURL("https://dev-api.glesys.test"),
+		WithHTTPClient(httpClient),
+		WithTimeout(5*time.Second),
+		WithUserAgent("test-application/0.0.1"),
+		WithRetryPolicy(DefaultRetryPolicy()),
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://dev-api.glesys.test", client.BaseURL.String(), "BaseURL is correct")
+	assert.Equal(t, 5*time.Second, client.httpClient.(*http.Client).Timeout, "Timeout is correct")
+	assert.Equal(t, time.Duration(0), httpClient.Timeout, "provided http client is not modified")
+	assert.Equal(t, DefaultRetryPolicy(), *client.retryPolicy, "RetryPolicy is correct")
+
+	request, _ := client.newRequest(context.Background(), "GET", "/", nil)
+	assert.Equal(t, "test-application/0.0.1 glesys-go/8.5.0", request.Header.Get("User-Agent"), "header User-Agent is correct")
+}
+
+func TestNewClientWithOptionsReturnsErrors(t *testing.T) {
+	_, err := NewClientWithOptions("project-id", "api-key", WithBaseURL(":invalid"))
+	assert.Error(t, err, "invalid BaseURL returns an error")
+
+	_, err = NewClientWithOptions("project-id", "api-key", WithHTTPClient(nil))
+	assert.Error(t, err, "nil http client returns an error")
+
+	_, err = NewClientWithOptions("project-id", "api-key", WithTimeout(-time.Second))
+	assert.Error(t, err, "negative timeout returns an error")
+}
+
+func TestWithTransportMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{ "response": {} }`))
+	}))
+	defer server.Close()
+
+	var order []string
+	middleware := func(name string) TransportMiddleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(request)
+			})
+		}
+	}
+
+	client, err := NewClientWithOptions("project-id", "api-key",
+		WithBaseURL(server.URL),
+		WithTransportMiddleware(middleware("first"), middleware("second")),
+	)
+	assert.NoError(t, err)
+
+	client.get(context.Background(), "server/list", nil)
+
+	assert.Equal(t, []string{"first", "second"}, order, "middleware is applied in order")
+}
+
+func TestWithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{ "response": {} }`))
+	}))
+	defer server.Close()
+
+	buffer := new(bytes.Buffer)
+	logger := slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, _ := NewClientWithOptions("project-id", "api-key", WithBaseURL(server.URL), WithLogger(logger))
+	client.get(context.Background(), "server/list", nil)
+
+	assert.Contains(t, buffer.String(), "method=GET", "method is logged")
+	assert.Contains(t, buffer.String(), "path=server/list", "path is logged")
+	assert.Contains(t, buffer.String(), "status=200", "status is logged")
+}
+
+func TestNewLoginWithOptions(t *testing.T) {
+	login, err := NewLoginWithOptions(
+		WithBaseURL("https://dev-api.glesys.test"),
+		WithUserAgent("test-application/0.0.1"),
+		WithRetryPolicy(DefaultRetryPolicy()),
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://dev-api.glesys.test", login.BaseURL.String(), "BaseURL is correct")
+	assert.Equal(t, "test-application/0.0.1", login.UserAgent, "UserAgent is correct")
+	assert.NotNil(t, login.retryPolicy, "RetryPolicy is set")
+	assert.NotNil(t, login.Users, "services are set up")
+}
@@ -0,0 +1,142 @@
+package glesys
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// pipeline sends requests to the API. It is shared by Client and Login, which
+// create a pipeline from their current configuration for every request.
+type pipeline struct {
+	baseURL     *url.URL
+	httpClient  httpClientInterface
+	logger      *slog.Logger
+	logOptions  LogOptions
+	metrics     MetricsRecorder
+	middleware  []Middleware
+	password    string
+	project     string
+	rateLimiter *RateLimiter
+	retryPolicy *RetryPolicy
+	userAgent   string
+	username    string
+}
+
+// call performs a request to path and decodes the response into v.
+func (p *pipeline) call(ctx context.Context, method, path string, v interface{}, params interface{}) error {
+	request, err := p.newRequest(ctx, method, path, params)
+	if err != nil {
+		return err
+	}
+	return p.do(&Request{
+		Method:      method,
+		Path:        strings.Trim(path, "/"),
+		Params:      params,
+		Project:     p.project,
+		HTTPRequest: request,
+	}, v)
+}
+
+func (p *pipeline) newRequest(ctx context.Context, method, path string, params interface{}) (*http.Request, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.baseURL != nil {
+		u = p.baseURL.ResolveReference(u)
+	}
+
+	buffer := new(bytes.Buffer)
+
+	if params != nil {
+		err = json.NewEncoder(buffer).Encode(params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, u.String(), buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	userAgent := strings.TrimSpace(fmt.Sprintf("%s glesys-go/%s", p.userAgent, version))
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", userAgent)
+	request.SetBasicAuth(p.username, p.password)
+
+	return request, nil
+}
+
+// do passes the request through the middleware chain and decodes the
+// response into v.
+func (p *pipeline) do(request *Request, v interface{}) error {
+	handler := Handler(p.send)
+	for i := len(p.middleware) - 1; i >= 0; i-- {
+		handler = p.middleware[i](handler)
+	}
+
+	response, err := handler(request)
+	if err != nil {
+		return err
+	}
+
+	if v == nil || response == nil {
+		return nil
+	}
+	return json.Unmarshal(response.Body, v)
+}
+
+// send is the innermost Handler of the middleware chain.
+func (p *pipeline) send(request *Request) (*Response, error) {
+	httpClient := p.httpClient
+	if p.rateLimiter != nil {
+		httpClient = &rateLimitedHTTPClient{
+			endpoint:   request.Endpoint(),
+			httpClient: httpClient,
+			limiter:    p.rateLimiter,
+			metrics:    p.metrics,
+			readOnly:   isReadOnly(request.Method, request.Path),
+		}
+	}
+
+	start := time.Now()
+	httpResponse, attempts, err := send(httpClient, p.retryPolicy, request.HTTPRequest, request.Path)
+	response, err := readResponse(request, httpResponse, attempts, err)
+	duration := time.Since(start)
+	logCall(p.logger, p.logOptions, request, response, err, duration)
+	recordCall(p.metrics, request, response, err, duration)
+	return response, err
+}
+
+// readResponse reads the body of the response and returns an *APIError if
+// the API responded with an error.
+func readResponse(request *Request, httpResponse *http.Response, attempts int, err error) (*Response, error) {
+	response := &Response{HTTPResponse: httpResponse, Attempts: attempts}
+	if err != nil {
+		return response, err
+	}
+
+	defer httpResponse.Body.Close()
+	response.Body, err = io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return response, err
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		httpResponse.Body = io.NopCloser(bytes.NewReader(response.Body))
+		return response, withPath(handleResponseError(httpResponse), request.Path)
+	}
+
+	return response, nil
+}
@@ -4,6 +4,22 @@ import (
 	"context"
 )
 
+// PrivateNetworkAPI is the interface implemented by PrivateNetworkService.
+type PrivateNetworkAPI interface {
+	Create(ctx context.Context, name string) (*PrivateNetwork, error)
+	Details(ctx context.Context, privateNetworkID string) (*PrivateNetwork, error)
+	List(ctx context.Context) (*[]PrivateNetwork, error)
+	Destroy(ctx context.Context, privateNetworkID string) error
+	Edit(ctx context.Context, params EditPrivateNetworkParams) (*PrivateNetwork, error)
+	EstimatedCost(ctx context.Context, privateNetworkID string) (*PrivateNetworkBilling, error)
+	CreateSegment(ctx context.Context, params CreatePrivateNetworkSegmentParams) (*PrivateNetworkSegment, error)
+	EditSegment(ctx context.Context, params EditPrivateNetworkSegmentParams) (*PrivateNetworkSegment, error)
+	ListSegments(ctx context.Context, privatenetworkid string) (*[]PrivateNetworkSegment, error)
+	DestroySegment(ctx context.Context, id string) error
+}
+
+var _ PrivateNetworkAPI = (*PrivateNetworkService)(nil)
+
 // PrivateNetworkService provides functions to interact with PrivateNetworks
 type PrivateNetworkService struct {
 	client clientInterface
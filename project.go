@@ -0,0 +1,53 @@
+package glesys
+
+import "context"
+
+type projectContextKey struct{}
+
+type projectCredentials struct {
+	project string
+	apiKey  string
+}
+
+// WithProject returns a copy of ctx which makes calls by a Client use project
+// and apiKey instead of the credentials of the Client. This makes it possible
+// to use a single Client for several projects. Calls by a Login are not
+// affected.
+func WithProject(ctx context.Context, project, apiKey string) context.Context {
+	return context.WithValue(ctx, projectContextKey{}, projectCredentials{project: project, apiKey: apiKey})
+}
+
+// ProjectFromContext returns the project set by WithProject, if any.
+func ProjectFromContext(ctx context.Context) (string, bool) {
+	credentials, ok := ctx.Value(projectContextKey{}).(projectCredentials)
+	return credentials.project, ok
+}
+
+// Project returns the project of the client.
+func (c *Client) Project() string {
+	return c.project
+}
+
+// ForProject returns a copy of the client which uses project and apiKey. The
+// copy shares the http client, rate limiter, middleware and other options
+// with c, which makes it cheap to create.
+func (c *Client) ForProject(project, apiKey string) *Client {
+	clone := *c
+	clone.project = project
+	clone.apiKey = apiKey
+
+	clone.Databases = &DatabaseService{client: &clone}
+	clone.DNSDomains = &DNSDomainService{client: &clone}
+	clone.EmailDomains = &EmailDomainService{client: &clone}
+	clone.IPs = &IPService{client: &clone}
+	clone.LoadBalancers = &LoadBalancerService{client: &clone}
+	clone.ObjectStorages = &ObjectStorageService{client: &clone}
+	clone.PrivateNetworks = &PrivateNetworkService{client: &clone}
+	clone.Servers = &ServerService{client: &clone, validator: c.Servers.validator.forProject()}
+	clone.ServerDisks = &ServerDisksService{client: &clone}
+	clone.Networks = &NetworkService{client: &clone}
+	clone.NetworkAdapters = &NetworkAdapterService{client: &clone}
+	clone.NetworkCircuits = &NetworkCircuitService{client: &clone}
+
+	return &clone
+}
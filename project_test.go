@@ -0,0 +1,58 @@
+package glesys
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithProject(t *testing.T) {
+	mockClient := &mockHTTPClient{body: `{ "response": { "server": { "serverid": "kvm12345" } } }`, statusCode: 200}
+
+	var seen *Request
+	client, _ := NewClientWithOptions("CL12345", "api-key", WithMiddleware(func(next Handler) Handler {
+		return func(request *Request) (*Response, error) {
+			seen = request
+			return next(request)
+		}
+	}))
+	client.httpClient = mockClient
+
+	ctx := WithProject(context.Background(), "CL67890", "other-api-key")
+	_, err := client.Servers.Details(ctx, "kvm12345")
+	assert.NoError(t, err)
+
+	username, password, _ := mockClient.lastRequest.BasicAuth()
+	assert.Equal(t, "CL67890", username, "project of context is used")
+	assert.Equal(t, "other-api-key", password, "api key of context is used")
+	assert.Equal(t, "CL67890", seen.Project, "middleware sees project of context")
+
+	project, ok := ProjectFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "CL67890", project)
+
+	client.Servers.Details(context.Background(), "kvm12345")
+	username, password, _ = mockClient.lastRequest.BasicAuth()
+	assert.Equal(t, "CL12345", username, "project of client is used without override")
+	assert.Equal(t, "api-key", password, "api key of client is used without override")
+}
+
+func TestForProject(t *testing.T) {
+	mockClient := &mockHTTPClient{body: `{ "response": { "server": { "serverid": "kvm12345" } } }`, statusCode: 200}
+	client, _ := NewClientWithOptions("CL12345", "api-key", WithRateLimiter(NewRateLimiter(RateLimit{ReadRate: 10, ReadBurst: 10, WriteRate: 10, WriteBurst: 10})))
+	client.httpClient = mockClient
+
+	other := client.ForProject("CL67890", "other-api-key")
+	assert.Equal(t, "CL67890", other.Project())
+	assert.Equal(t, "CL12345", client.Project(), "original client is not modified")
+	assert.Same(t, client.RateLimiter(), other.RateLimiter(), "rate limiter is shared")
+	assert.Equal(t, client.httpClient, other.httpClient, "http client is shared")
+	assert.NotSame(t, client.DNSDomains, other.DNSDomains, "services are bound to the clone")
+
+	_, err := other.Servers.Details(context.Background(), "kvm12345")
+	assert.NoError(t, err)
+	username, password, _ := mockClient.lastRequest.BasicAuth()
+	assert.Equal(t, "CL67890", username, "project of clone is used")
+	assert.Equal(t, "other-api-key", password, "api key of clone is used")
+}
@@ -0,0 +1,164 @@
+package glesys
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimit configures a RateLimiter. Rates are given in requests per second
+// and a rate of zero disables limiting for that kind of call.
+type RateLimit struct {
+	// ReadRate limits read-only calls such as `server/details`.
+	ReadRate float64
+	// ReadBurst is the number of read-only calls allowed in a burst.
+	ReadBurst int
+	// WriteRate limits mutating calls such as `domain/addrecord`.
+	WriteRate float64
+	// WriteBurst is the number of mutating calls allowed in a burst.
+	WriteBurst int
+}
+
+// RateLimiterStats describes how much calls have been throttled.
+type RateLimiterStats struct {
+	// Waits is the number of calls which had to wait.
+	Waits int64
+	// TotalWait is the total time calls have waited.
+	TotalWait time.Duration
+	// LastWait is the time the most recent throttled call waited.
+	LastWait time.Duration
+	// Waiting is the number of calls currently waiting.
+	Waiting int
+}
+
+// RateLimiter is a client-side token bucket limiter with separate budgets for
+// read-only and mutating calls. It is safe for concurrent use and shared by
+// all services of a Client.
+type RateLimiter struct {
+	mu    sync.Mutex
+	read  tokenBucket
+	write tokenBucket
+	stats RateLimiterStats
+}
+
+// NewRateLimiter creates a new RateLimiter.
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	now := time.Now()
+	return &RateLimiter{
+		read:  newTokenBucket(limit.ReadRate, limit.ReadBurst, now),
+		write: newTokenBucket(limit.WriteRate, limit.WriteBurst, now),
+	}
+}
+
+// WithRateLimiter limits the rate of calls made by the client. The same
+// limiter may be shared by several clients.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *options) error {
+		o.rateLimiter = limiter
+		return nil
+	}
+}
+
+// Wait blocks until a call is allowed or ctx is done. It returns the time
+// spent waiting.
+func (l *RateLimiter) Wait(ctx context.Context, readOnly bool) (time.Duration, error) {
+	l.mu.Lock()
+	bucket := &l.write
+	if readOnly {
+		bucket = &l.read
+	}
+	delay := bucket.reserve(time.Now())
+	if delay <= 0 {
+		l.mu.Unlock()
+		return 0, nil
+	}
+	l.stats.Waiting++
+	l.mu.Unlock()
+
+	err := sleep(ctx, delay)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stats.Waiting--
+	if err != nil {
+		bucket.cancel()
+		return 0, err
+	}
+	l.stats.Waits++
+	l.stats.TotalWait += delay
+	l.stats.LastWait = delay
+	return delay, nil
+}
+
+// Stats returns statistics about throttled calls.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+// tokenBucket hands out reservations for tokens which are refilled at rate
+// tokens per second, up to burst tokens.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: now}
+}
+
+// reserve takes a token and returns how long to wait before it is available.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	if b.rate <= 0 {
+		return 0
+	}
+
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a token which was reserved but not used.
+func (b *tokenBucket) cancel() {
+	if b.rate > 0 {
+		b.tokens++
+	}
+}
+
+// rateLimitedHTTPClient waits for the rate limiter before every request,
+// including retries.
+type rateLimitedHTTPClient struct {
+	endpoint   string
+	httpClient httpClientInterface
+	limiter    *RateLimiter
+	metrics    MetricsRecorder
+	readOnly   bool
+}
+
+func (c *rateLimitedHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	wait, err := c.limiter.Wait(request.Context(), c.readOnly)
+	if err != nil {
+		return nil, err
+	}
+	if wait > 0 && c.metrics != nil {
+		c.metrics.RecordRateLimitWait(c.endpoint, wait)
+	}
+	return c.httpClient.Do(request)
+}
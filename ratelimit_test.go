@@ -0,0 +1,95 @@
+package glesys
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(10, 2, now)
+
+	assert.Equal(t, time.Duration(0), bucket.reserve(now), "burst is available")
+	assert.Equal(t, time.Duration(0), bucket.reserve(now), "burst is available")
+	assert.Equal(t, 100*time.Millisecond, bucket.reserve(now), "third call waits for a token")
+	assert.Equal(t, 200*time.Millisecond, bucket.reserve(now), "fourth call waits for two tokens")
+
+	bucket.cancel()
+	assert.Equal(t, 200*time.Millisecond, bucket.reserve(now), "cancelled tokens are returned")
+
+	later := now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), bucket.reserve(later), "tokens are refilled")
+}
+
+func TestTokenBucketUnlimited(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(0, 0, now)
+
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, time.Duration(0), bucket.reserve(now), "zero rate is unlimited")
+	}
+}
+
+func TestRateLimiterSeparateBudgets(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{ReadRate: 1, ReadBurst: 1, WriteRate: 1, WriteBurst: 1})
+
+	delay, err := limiter.Wait(context.Background(), true)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), delay, "first read does not wait")
+
+	delay, err = limiter.Wait(context.Background(), false)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), delay, "write budget is separate from read budget")
+}
+
+func TestRateLimiterWaitHonoursContext(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{WriteRate: 0.1, WriteBurst: 1})
+	limiter.Wait(context.Background(), false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := limiter.Wait(ctx, false)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, RateLimiterStats{}, limiter.Stats(), "cancelled waits are not counted")
+}
+
+func TestRateLimiterStats(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{ReadRate: 100, ReadBurst: 1})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limiter.Wait(context.Background(), true)
+		}()
+	}
+	wg.Wait()
+
+	stats := limiter.Stats()
+	assert.Equal(t, int64(2), stats.Waits, "two calls waited")
+	assert.True(t, stats.TotalWait >= 20*time.Millisecond, "total wait is recorded")
+	assert.True(t, stats.LastWait > 0, "last wait is recorded")
+	assert.Equal(t, 0, stats.Waiting, "no calls are waiting")
+}
+
+func TestClientWithRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{ReadRate: 100, ReadBurst: 1, WriteRate: 100, WriteBurst: 1})
+	client, _ := NewClientWithOptions("project-id", "api-key", WithRateLimiter(limiter))
+	client.httpClient = &mockHTTPClient{body: `{ "response": {} }`, statusCode: 200}
+
+	assert.Equal(t, limiter, client.RateLimiter(), "rate limiter is exposed")
+
+	client.Servers.Details(context.Background(), "kvm12345")
+	client.IPs.Reserved(context.Background(), ReservedIPsParams{})
+	client.DNSDomains.AddRecord(context.Background(), AddRecordParams{})
+
+	stats := limiter.Stats()
+	assert.Equal(t, int64(1), stats.Waits, "read calls share a budget across services")
+}
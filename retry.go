@@ -0,0 +1,266 @@
+package glesys
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RetryPolicy describes how failed requests are retried. Requests are retried
+// with an exponential backoff and jitter, honouring the Retry-After header on
+// 429 and 503 responses.
+//
+// Read-only endpoints (GET requests and POST requests such as `server/templates`
+// or `ip/listown`) are retried on transport errors and on 429, 502, 503 and 504
+// responses. Mutating endpoints such as `server/create` are only retried on 429
+// responses, since the API rejected those requests without processing them.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// A value less than 2 disables retries.
+	MaxAttempts int
+	// MaxElapsed limits the total time spent on a request including backoff.
+	// Zero means that only the deadline of the request context applies.
+	MaxElapsed time.Duration
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration
+	// Jitter is the fraction (0-1) of each delay that is randomized.
+	Jitter float64
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with sensible defaults.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		MaxElapsed:     2 * time.Minute,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Jitter:         0.2,
+	}
+}
+
+// readOnlyEndpoints lists POST endpoints which do not modify any resources and
+// therefore are safe to retry.
+var readOnlyEndpoints = map[string]bool{
+	"customer/listprojects":         true,
+	"database/estimatedcost":        true,
+	"domain/available":              true,
+	"domain/details":                true,
+	"domain/export":                 true,
+	"domain/list":                   true,
+	"domain/listrecords":            true,
+	"email/costs":                   true,
+	"email/list":                    true,
+	"email/overview":                true,
+	"email/quota":                   true,
+	"ip/details":                    true,
+	"ip/listfree":                   true,
+	"ip/listown":                    true,
+	"loadbalancer/listcertificate":  true,
+	"networkcircuit/details":        true,
+	"networkcircuit/list":           true,
+	"objectstorage/instancedetails": true,
+	"objectstorage/listinstances":   true,
+	"privatenetwork/details":        true,
+	"privatenetwork/estimatedcost":  true,
+	"privatenetwork/list":           true,
+	"privatenetwork/listsegments":   true,
+	"server/allowedarguments":       true,
+	"server/costs":                  true,
+	"server/estimatedcost":          true,
+	"server/listiso":                true,
+	"server/networkadapters":        true,
+	"server/previewcloudconfig":     true,
+	"server/resourceusage":          true,
+	"server/status":                 true,
+	"server/templates":              true,
+	"serverdisk/limits":             true,
+	"user/listorganizations":        true,
+}
+
+// isReadOnly returns true if a request to path is safe to retry.
+func isReadOnly(method, path string) bool {
+	if method == http.MethodGet || method == http.MethodHead {
+		return true
+	}
+	return readOnlyEndpoints[endpoint(path)]
+}
+
+// endpoint returns the module and function of an API path, e.g.
+// `server/details` for `server/details/serverid/kvm123456`.
+func endpoint(path string) string {
+	sections := strings.SplitN(strings.Trim(path, "/"), "/", 3)
+	if len(sections) > 2 {
+		sections = sections[:2]
+	}
+	return strings.ToLower(strings.Join(sections, "/"))
+}
+
+// apiPath returns the path of u relative to the base URL.
+func apiPath(base, u *url.URL) string {
+	path := u.Path
+	if base != nil {
+		path = strings.TrimPrefix(path, strings.TrimSuffix(base.Path, "/"))
+	}
+	return strings.Trim(path, "/")
+}
+
+// shouldRetry decides if a request should be attempted again based on the
+// outcome of the previous attempt.
+func (p *RetryPolicy) shouldRetry(readOnly bool, response *http.Response, err error) bool {
+	if err != nil {
+		return readOnly
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return readOnly
+	}
+	return false
+}
+
+var (
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	jitterMu   sync.Mutex
+)
+
+// backoff returns the delay before the given retry, starting at 1.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	if jitter := p.Jitter; jitter > 0 {
+		if jitter > 1 {
+			jitter = 1
+		}
+		jitterMu.Lock()
+		r := jitterRand.Float64()
+		jitterMu.Unlock()
+		delay -= time.Duration(float64(delay) * jitter * r)
+	}
+	return delay
+}
+
+// retryAfter parses the Retry-After header of 429 and 503 responses. Both the
+// delay-seconds and the HTTP-date format are supported.
+func retryAfter(response *http.Response, now time.Time) (time.Duration, bool) {
+	if response == nil {
+		return 0, false
+	}
+	if response.StatusCode != http.StatusTooManyRequests && response.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	value := strings.TrimSpace(response.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// send performs the request and retries it according to policy. A nil policy
+// disables retries. The number of attempts is returned with the response.
+func send(httpClient httpClientInterface, policy *RetryPolicy, request *http.Request, path string) (*http.Response, int, error) {
+	start := time.Now()
+	response, err := httpClient.Do(request)
+	attempts := 1
+	if policy == nil || policy.MaxAttempts < 2 {
+		return response, attempts, err
+	}
+
+	ctx := request.Context()
+	readOnly := isReadOnly(request.Method, path)
+
+	for attempt := 1; attempt < policy.MaxAttempts; attempt++ {
+		if ctx.Err() != nil || !policy.shouldRetry(readOnly, response, err) {
+			break
+		}
+		if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
+			break
+		}
+
+		delay, ok := retryAfter(response, time.Now())
+		if !ok {
+			delay = policy.backoff(attempt)
+		}
+		if policy.MaxElapsed > 0 && time.Since(start)+delay > policy.MaxElapsed {
+			break
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			break
+		}
+
+		retry, rerr := rewind(request)
+		if rerr != nil {
+			break
+		}
+
+		if response != nil {
+			io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		if serr := sleep(ctx, delay); serr != nil {
+			return nil, attempts, serr
+		}
+
+		response, err = httpClient.Do(retry)
+		attempts++
+	}
+
+	return response, attempts, err
+}
+
+// rewind returns a copy of request with a fresh body.
+func rewind(request *http.Request) (*http.Request, error) {
+	retry := request.Clone(request.Context())
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return retry, nil
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
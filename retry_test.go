@@ -0,0 +1,261 @@
+package glesys
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sequenceHTTPClient struct {
+	responses []*http.Response
+	errors    []error
+	bodies    []string
+	calls     int
+	delay     time.Duration
+}
+
+func (c *sequenceHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	i := c.calls
+	c.calls++
+	time.Sleep(c.delay)
+
+	if request.Body != nil {
+		body, _ := io.ReadAll(request.Body)
+		c.bodies = append(c.bodies, string(body))
+	}
+
+	if i < len(c.errors) && c.errors[i] != nil {
+		return nil, c.errors[i]
+	}
+	return c.responses[i], nil
+}
+
+func newTestResponse(statusCode int, body string, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func testRetryPolicyPointer() *RetryPolicy {
+	policy := testRetryPolicy()
+	return &policy
+}
+
+func TestRetryReadOnlyRequestOnServiceUnavailable(t *testing.T) {
+	httpClient := &sequenceHTTPClient{responses: []*http.Response{
+		newTestResponse(503, `{}`, nil),
+		newTestResponse(200, `{ "response": { "message": "Hello World" } }`, nil),
+	}}
+	client := Client{httpClient: httpClient}
+	client.retryPolicy = testRetryPolicyPointer()
+
+	data := struct{ Response struct{ Message string } }{}
+	err := client.get(context.Background(), "server/list", &data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, httpClient.calls, "request was retried")
+	assert.Equal(t, "Hello World", data.Response.Message, "JSON was parsed correctly")
+}
+
+func TestRetryMaxElapsedIncludesFirstAttempt(t *testing.T) {
+	httpClient := &sequenceHTTPClient{responses: []*http.Response{
+		newTestResponse(503, `{}`, nil),
+		newTestResponse(200, `{}`, nil),
+	}, delay: 20 * time.Millisecond}
+	client := Client{httpClient: httpClient}
+	client.retryPolicy = testRetryPolicyPointer()
+	client.retryPolicy.MaxElapsed = 15 * time.Millisecond
+
+	err := client.get(context.Background(), "server/list", nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, httpClient.calls, "slow first attempt uses up the budget")
+}
+
+func TestRetryServerStatusOnServiceUnavailable(t *testing.T) {
+	httpClient := &sequenceHTTPClient{responses: []*http.Response{
+		newTestResponse(503, `{}`, nil),
+		newTestResponse(200, `{ "response": { "server": { "state": "running" } } }`, nil),
+	}}
+	client := Client{httpClient: httpClient}
+	client.retryPolicy = testRetryPolicyPointer()
+	s := ServerService{client: &client}
+
+	status, err := s.Status(context.Background(), "kvm123456")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, httpClient.calls, "request was retried")
+	assert.Equal(t, "running", status.State)
+}
+
+func TestRetryRewindsRequestBody(t *testing.T) {
+	httpClient := &sequenceHTTPClient{responses: []*http.Response{
+		newTestResponse(502, `{}`, nil),
+		newTestResponse(200, `{}`, nil),
+	}}
+	client := Client{httpClient: httpClient}
+	client.retryPolicy = testRetryPolicyPointer()
+
+	err := client.post(context.Background(), "server/templates", nil, map[string]string{"foo": "bar"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, httpClient.calls, "request was retried")
+	assert.Equal(t, httpClient.bodies[0], httpClient.bodies[1], "body is sent on every attempt")
+}
+
+func TestRetryDoesNotRetryMutatingRequestOnServiceUnavailable(t *testing.T) {
+	httpClient := &sequenceHTTPClient{responses: []*http.Response{
+		newTestResponse(503, `{ "response": { "status": { "code": 503, "text": "Unavailable" } } }`, nil),
+		newTestResponse(200, `{}`, nil),
+	}}
+	client := Client{httpClient: httpClient}
+	client.retryPolicy = testRetryPolicyPointer()
+
+	err := client.post(context.Background(), "server/create", nil, CreateServerParams{})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, httpClient.calls, "mutating request was not retried")
+}
+
+func TestRetryMutatingRequestOnTooManyRequests(t *testing.T) {
+	httpClient := &sequenceHTTPClient{responses: []*http.Response{
+		newTestResponse(429, `{}`, http.Header{"Retry-After": []string{"0"}}),
+		newTestResponse(200, `{}`, nil),
+	}}
+	client := Client{httpClient: httpClient}
+	client.retryPolicy = testRetryPolicyPointer()
+
+	err := client.post(context.Background(), "server/create", nil, CreateServerParams{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, httpClient.calls, "rate limited request was retried")
+}
+
+func TestRetryTransportErrors(t *testing.T) {
+	httpClient := &sequenceHTTPClient{
+		errors:    []error{errors.New("connection reset"), errors.New("connection reset"), errors.New("connection reset")},
+		responses: []*http.Response{nil, nil, nil},
+	}
+	client := Client{httpClient: httpClient}
+	client.retryPolicy = testRetryPolicyPointer()
+
+	err := client.get(context.Background(), "server/list", nil)
+
+	assert.EqualError(t, err, "connection reset")
+	assert.Equal(t, 3, httpClient.calls, "request was attempted MaxAttempts times")
+
+	httpClient = &sequenceHTTPClient{
+		errors:    []error{errors.New("connection reset")},
+		responses: []*http.Response{nil},
+	}
+	client.httpClient = httpClient
+
+	err = client.post(context.Background(), "loadbalancer/addtarget", nil, AddTargetParams{})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, httpClient.calls, "mutating request was not retried")
+}
+
+func TestRetryHonoursContextDeadline(t *testing.T) {
+	httpClient := &sequenceHTTPClient{responses: []*http.Response{
+		newTestResponse(429, `{}`, http.Header{"Retry-After": []string{"60"}}),
+		newTestResponse(200, `{}`, nil),
+	}}
+	client := Client{httpClient: httpClient}
+	client.retryPolicy = testRetryPolicyPointer()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := client.get(ctx, "server/list", nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, httpClient.calls, "retry beyond the deadline was not attempted")
+}
+
+func TestRetryDisabledWithoutPolicy(t *testing.T) {
+	httpClient := &sequenceHTTPClient{responses: []*http.Response{
+		newTestResponse(503, `{}`, nil),
+	}}
+	client := Client{httpClient: httpClient}
+
+	err := client.get(context.Background(), "server/list", nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, httpClient.calls, "request was not retried")
+}
+
+func TestRetryLogin(t *testing.T) {
+	httpClient := &sequenceHTTPClient{responses: []*http.Response{
+		newTestResponse(503, `{}`, nil),
+		newTestResponse(200, `{ "response": { "organizations": [{ "id": 1337 }] } }`, nil),
+	}}
+	login := NewLogin("")
+	login.httpClient = httpClient
+	login.retryPolicy = testRetryPolicyPointer()
+
+	orgs, err := login.Users.ListOrganizations(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, httpClient.calls, "request was retried")
+	assert.Equal(t, 1337, (*orgs)[0].ID, "Organization ID is correct")
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	delay, ok := retryAfter(newTestResponse(429, "", http.Header{"Retry-After": []string{"5"}}), now)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, delay, "delay-seconds is parsed")
+
+	header := http.Header{"Retry-After": []string{now.Add(10 * time.Second).Format(http.TimeFormat)}}
+	delay, ok = retryAfter(newTestResponse(503, "", header), now)
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Second, delay, "HTTP-date is parsed")
+
+	_, ok = retryAfter(newTestResponse(500, "", http.Header{"Retry-After": []string{"5"}}), now)
+	assert.False(t, ok, "Retry-After is ignored for other status codes")
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 400*time.Millisecond, policy.backoff(3))
+	assert.Equal(t, time.Second, policy.backoff(10), "backoff is capped")
+
+	policy.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		delay := policy.backoff(2)
+		assert.True(t, delay > 100*time.Millisecond && delay <= 200*time.Millisecond, "jitter is applied")
+	}
+}
+
+func TestIsReadOnly(t *testing.T) {
+	assert.True(t, isReadOnly("GET", "server/details/serverid/kvm123456/includestate/yes"))
+	assert.True(t, isReadOnly("POST", "server/templates"))
+	assert.True(t, isReadOnly("POST", "ip/listown"))
+	assert.True(t, isReadOnly("POST", "server/allowedarguments"))
+	assert.True(t, isReadOnly("POST", "server/costs"))
+	assert.True(t, isReadOnly("POST", "server/estimatedcost"))
+	assert.True(t, isReadOnly("POST", "server/resourceusage"))
+	assert.True(t, isReadOnly("POST", "server/status"))
+	assert.False(t, isReadOnly("POST", "server/create"))
+	assert.False(t, isReadOnly("POST", "loadbalancer/addtarget"))
+}
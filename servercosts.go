@@ -0,0 +1,166 @@
+package glesys
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ServerCost is an amount per time period.
+type ServerCost struct {
+	Amount     float64 `json:"amount"`
+	Currency   string  `json:"currency"`
+	Timeperiod string  `json:"timeperiod"`
+}
+
+// ServerCosts is the cost of a server broken down per resource.
+type ServerCosts struct {
+	CPU       ServerCost                `json:"cpu"`
+	Memory    ServerCost                `json:"memory"`
+	Storage   ServerCost                `json:"storage"`
+	Bandwidth ServerCost                `json:"bandwidth"`
+	License   ServerTemplateLicenseCost `json:"license"`
+	Backup    ServerCost                `json:"backup"`
+	Total     ServerCost                `json:"total"`
+}
+
+// ServerEstimate is the estimated cost of a new server, see EstimateServer.
+type ServerEstimate struct {
+	// Costs are the costs estimated by the API.
+	Costs ServerCosts
+	// Instance is the instance cost of the template.
+	Instance ServerTemplateInstanceCost
+	// License is the license cost of the template, unless already included
+	// in Costs.
+	License ServerTemplateLicenseCost
+	// ApproximateDisks approximates the cost of the additional disks by
+	// pricing them like the storage of the server, regardless of their Type.
+	// It is not included in Total since the API does not provide the prices
+	// of disks.
+	ApproximateDisks ServerCost
+	// Total is the sum of Costs, Instance and License.
+	Total ServerCost
+}
+
+// ServerCostChange is the change in cost of a server edit, see
+// EstimateServerEdit.
+type ServerCostChange struct {
+	Current   ServerCosts
+	Estimated ServerCosts
+	// Difference is the estimated total minus the current total.
+	Difference ServerCost
+}
+
+// EstimateServer estimates the cost of a new server created with params. The
+// estimate of the API is completed with the instance and license costs of the
+// template. The cost of the additional disks is approximated separately, see
+// ServerEstimate.ApproximateDisks. An error is returned if a cost is in
+// another currency or per another time period than the estimate of the API.
+func EstimateServer(ctx context.Context, servers ServerAPI, params CreateServerParams, disks ...CreateServerDiskParams) (*ServerEstimate, error) {
+	costs, err := servers.EstimatedCost(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := servers.Templates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	template, err := templates.Lookup(params.Platform, params.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &ServerEstimate{Costs: *costs, Instance: template.InstanceCost, Total: costs.Total}
+	if costs.License.Amount == 0 {
+		estimate.License = template.LicenseCost
+	}
+
+	estimate.ApproximateDisks = ServerCost{Currency: costs.Storage.Currency, Timeperiod: costs.Storage.Timeperiod}
+	if params.Storage > 0 {
+		perGiB := costs.Storage.Amount / float64(params.Storage)
+		for _, disk := range disks {
+			estimate.ApproximateDisks.Amount += perGiB * float64(disk.SizeInGIB)
+		}
+	}
+
+	for _, cost := range []ServerCost{
+		ServerCost(estimate.Instance),
+		ServerCost(estimate.License),
+	} {
+		if cost.Amount == 0 {
+			continue
+		}
+		if cost.Currency != "" && !strings.EqualFold(cost.Currency, estimate.Total.Currency) {
+			return nil, fmt.Errorf("glesys: can not add cost in %s to estimate in %s", cost.Currency, estimate.Total.Currency)
+		}
+		if cost.Timeperiod != "" && !strings.EqualFold(cost.Timeperiod, estimate.Total.Timeperiod) {
+			return nil, fmt.Errorf("glesys: can not add cost per %s to estimate per %s", cost.Timeperiod, estimate.Total.Timeperiod)
+		}
+		estimate.Total.Amount += cost.Amount
+	}
+	return estimate, nil
+}
+
+// EstimateServerEdit estimates the change in cost of editing a server with
+// params, by comparing the current costs of the server with the estimated
+// costs of a server with the edited configuration.
+func EstimateServerEdit(ctx context.Context, servers ServerAPI, serverID string, params EditServerParams) (*ServerCostChange, error) {
+	current, err := servers.Costs(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	server, err := servers.Details(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	edited := createServerParamsFromDetails(server)
+	if params.CPU != 0 {
+		edited.CPU = params.CPU
+	}
+	if params.Memory != 0 {
+		edited.Memory = params.Memory
+	}
+	if params.Storage != 0 {
+		edited.Storage = params.Storage
+	}
+	if params.Bandwidth != 0 {
+		edited.Bandwidth = params.Bandwidth
+	}
+	if params.Backup != nil {
+		edited.Backup = params.Backup
+	}
+
+	estimated, err := servers.EstimatedCost(ctx, edited)
+	if err != nil {
+		return nil, err
+	}
+	return &ServerCostChange{
+		Current:   *current,
+		Estimated: *estimated,
+		Difference: ServerCost{
+			Amount:     estimated.Total.Amount - current.Total.Amount,
+			Currency:   estimated.Total.Currency,
+			Timeperiod: estimated.Total.Timeperiod,
+		},
+	}, nil
+}
+
+// createServerParamsFromDetails returns the parameters of a server with the
+// configuration of server.
+func createServerParamsFromDetails(server *ServerDetails) CreateServerParams {
+	template := server.Template
+	if template == "" {
+		template = server.InitialTemplate.Name
+	}
+	return CreateServerParams{
+		Backup:     server.Backup.Schedules,
+		Bandwidth:  server.Bandwidth,
+		CPU:        server.CPU,
+		DataCenter: server.DataCenter,
+		Memory:     server.Memory,
+		Platform:   server.Platform,
+		Storage:    server.Storage,
+		Template:   template,
+	}
+}
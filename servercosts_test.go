@@ -0,0 +1,112 @@
+package glesys
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const estimatedCostBody = `{"response": {"estimatedcost": {
+	"cpu": {"amount": 100, "currency": "SEK", "timeperiod": "month"},
+	"memory": {"amount": 80, "currency": "SEK", "timeperiod": "month"},
+	"storage": {"amount": 50, "currency": "SEK", "timeperiod": "month"},
+	"bandwidth": {"amount": 0, "currency": "SEK", "timeperiod": "month"},
+	"license": {"amount": 0, "currency": "SEK", "timeperiod": "month"},
+	"backup": {"amount": 20, "currency": "SEK", "timeperiod": "month"},
+	"total": {"amount": 250, "currency": "SEK", "timeperiod": "month"}
+}}}`
+
+func TestServersCosts(t *testing.T) {
+	c := &mockClient{body: `{"response": {"costs": {
+		"cpu": {"amount": 100, "currency": "SEK", "timeperiod": "month"},
+		"license": {"amount": 300, "currency": "SEK", "timeperiod": "month"},
+		"total": {"amount": 400, "currency": "SEK", "timeperiod": "month"}
+	}}}`}
+	s := ServerService{client: c}
+
+	costs, err := s.Costs(context.Background(), "wps123456")
+	assert.NoError(t, err)
+	assert.Equal(t, "POST", c.lastMethod, "method used is correct")
+	assert.Equal(t, "server/costs", c.lastPath, "path used is correct")
+	assert.Equal(t, 100.0, costs.CPU.Amount)
+	assert.Equal(t, ServerTemplateLicenseCost{Amount: 300, Currency: "SEK", Timeperiod: "month"}, costs.License)
+	assert.Equal(t, 400.0, costs.Total.Amount)
+}
+
+func TestServersEstimatedCost(t *testing.T) {
+	c := &mockClient{body: estimatedCostBody}
+	s := ServerService{client: c}
+
+	costs, err := s.EstimatedCost(context.Background(), CreateServerParams{}.WithDefaults())
+	assert.NoError(t, err)
+	assert.Equal(t, "server/estimatedcost", c.lastPath, "path used is correct")
+	assert.Equal(t, 20.0, costs.Backup.Amount)
+	assert.Equal(t, ServerCost{Amount: 250, Currency: "SEK", Timeperiod: "month"}, costs.Total)
+}
+
+func TestEstimateServer(t *testing.T) {
+	templates := `{"response": {"templates": {"VMware": [{
+		"name": "Windows Server 2022 Standard", "platform": "VMware",
+		"instancecost": {"amount": 10, "currency": "SEK", "timeperiod": "month"},
+		"licensecost": {"amount": 200, "currency": "SEK", "timeperiod": "month"}
+	}]}}}`
+	c := &sequenceClient{bodies: []string{estimatedCostBody, templates}}
+	s := ServerService{client: c}
+
+	params := CreateServerParams{Platform: "VMware", Template: "Windows Server 2022 Standard", Storage: 50}
+	estimate, err := EstimateServer(context.Background(), &s, params, CreateServerDiskParams{SizeInGIB: 100}, CreateServerDiskParams{SizeInGIB: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, estimate.Instance.Amount, "instance cost of the template is included")
+	assert.Equal(t, 200.0, estimate.License.Amount, "license cost of the template is included")
+	assert.Equal(t, ServerCost{Amount: 110, Currency: "SEK", Timeperiod: "month"}, estimate.ApproximateDisks, "disks are priced like storage")
+	assert.Equal(t, ServerCost{Amount: 460, Currency: "SEK", Timeperiod: "month"}, estimate.Total, "disks are not included in the total")
+
+	c = &sequenceClient{bodies: []string{estimatedCostBody, templates}}
+	s = ServerService{client: c}
+	params.Template = "Windows Server 2019"
+	_, err = EstimateServer(context.Background(), &s, params)
+	assert.ErrorIs(t, err, ErrTemplateNotFound)
+
+	hourly := `{"response": {"templates": {"VMware": [{
+		"name": "Windows Server 2022 Standard", "platform": "VMware",
+		"licensecost": {"amount": 0.3, "currency": "SEK", "timeperiod": "hour"}
+	}]}}}`
+	c = &sequenceClient{bodies: []string{estimatedCostBody, hourly}}
+	s = ServerService{client: c}
+	params.Template = "Windows Server 2022 Standard"
+	_, err = EstimateServer(context.Background(), &s, params)
+	assert.EqualError(t, err, "glesys: can not add cost per hour to estimate per month", "costs per other time periods are rejected")
+}
+
+func TestEstimateServerEdit(t *testing.T) {
+	current := `{"response": {"costs": {"total": {"amount": 200, "currency": "SEK", "timeperiod": "month"}}}}`
+	server := `{"response": {"server": {"serverid": "kvm123456", "platform": "KVM", "cpucores": 1, "memorysize": 1024,
+		"disksize": 50, "bandwidth": 100, "datacenter": "Falkenberg", "initialtemplate": {"name": "Debian 12 (Bookworm)"}}}}`
+	c := &sequenceClient{bodies: []string{current, server, estimatedCostBody}}
+	s := ServerService{client: c}
+
+	change, err := EstimateServerEdit(context.Background(), &s, "kvm123456", EditServerParams{CPU: 2, Memory: 2048})
+	assert.NoError(t, err)
+	assert.Equal(t, 200.0, change.Current.Total.Amount)
+	assert.Equal(t, 250.0, change.Estimated.Total.Amount)
+	assert.Equal(t, ServerCost{Amount: 50, Currency: "SEK", Timeperiod: "month"}, change.Difference)
+}
+
+func TestCreateServerParamsFromDetails(t *testing.T) {
+	server := &ServerDetails{
+		Backup:          ServerBackupDetails{Schedules: []ServerBackupSchedule{{Frequency: "daily", Numberofimagestokeep: 7}}},
+		Bandwidth:       100,
+		CPU:             2,
+		DataCenter:      "Falkenberg",
+		InitialTemplate: ServerTemplateDetails{Name: "Debian 12 (Bookworm)"},
+		Memory:          2048,
+		Platform:        "KVM",
+		Storage:         50,
+	}
+
+	params := createServerParamsFromDetails(server)
+	assert.Equal(t, "Debian 12 (Bookworm)", params.Template, "initial template is used")
+	assert.Equal(t, server.Backup.Schedules, params.Backup)
+	assert.Equal(t, 2048, params.Memory)
+}
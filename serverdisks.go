@@ -2,6 +2,17 @@ package glesys
 
 import "context"
 
+// ServerDisksAPI is the interface implemented by ServerDisksService.
+type ServerDisksAPI interface {
+	Create(ctx context.Context, params CreateServerDiskParams) (*ServerDiskDetails, error)
+	UpdateName(ctx context.Context, params EditServerDiskParams) (*ServerDiskDetails, error)
+	Reconfigure(ctx context.Context, params EditServerDiskParams) (*ServerDiskDetails, error)
+	Delete(ctx context.Context, diskID string) error
+	Limits(ctx context.Context, serverID string) (*ServerDiskLimitsDetails, error)
+}
+
+var _ ServerDisksAPI = (*ServerDisksService)(nil)
+
 // ServerDisksService provides functions to interact with serverdisks
 type ServerDisksService struct {
 	client clientInterface
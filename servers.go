@@ -11,9 +11,35 @@ import (
 	"dario.cat/mergo"
 )
 
+// ServerAPI is the interface implemented by ServerService.
+type ServerAPI interface {
+	AllowedArguments(ctx context.Context) (*ServerAllowedArguments, error)
+	Create(ctx context.Context, params CreateServerParams) (*ServerDetails, error)
+	Clone(ctx context.Context, serverID string, params CloneServerParams) (*ServerDetails, error)
+	Console(ctx context.Context, serverID string) (*ServerConsoleDetails, error)
+	Costs(ctx context.Context, serverID string) (*ServerCosts, error)
+	Destroy(ctx context.Context, serverID string, params DestroyServerParams) error
+	Details(ctx context.Context, serverID string) (*ServerDetails, error)
+	Edit(ctx context.Context, serverID string, params EditServerParams) (*ServerDetails, error)
+	EstimatedCost(ctx context.Context, params CreateServerParams) (*ServerCosts, error)
+	List(ctx context.Context) (*[]Server, error)
+	NetworkAdapters(ctx context.Context, serverID string) (*[]NetworkAdapter, error)
+	PreviewCloudConfig(ctx context.Context, params PreviewCloudConfigParams) (*CloudConfigPreview, error)
+	ResourceUsage(ctx context.Context, serverID string, params ServerResourceUsageParams) (*ServerResourceUsage, error)
+	ListISOs(ctx context.Context, serverID string) (*[]string, error)
+	MountISO(ctx context.Context, serverID string, isoFile string) (*ServerDetails, error)
+	Templates(ctx context.Context) (*ServerPlatformTemplates, error)
+	Start(ctx context.Context, serverID string) error
+	Status(ctx context.Context, serverID string) (*ServerStatus, error)
+	Stop(ctx context.Context, serverID string, params StopServerParams) error
+}
+
+var _ ServerAPI = (*ServerService)(nil)
+
 // ServerService provides functions to interact with servers
 type ServerService struct {
-	client clientInterface
+	client    clientInterface
+	validator *allowedArgumentsCache
 }
 
 // Server is a simplified version of a server
@@ -221,10 +247,29 @@ func (s *ServerService) Create(context context.Context, params CreateServerParam
 			Server ServerDetails
 		}
 	}{}
+	if s.validator != nil {
+		if err := s.validator.validateCreate(context, s, params); err != nil {
+			return nil, err
+		}
+	}
 	err := s.client.post(context, "server/create", &data, params)
 	return &data.Response.Server, err
 }
 
+// Clone creates a copy of a server, see also ServerDetails.CloneParams
+func (s *ServerService) Clone(context context.Context, serverID string, params CloneServerParams) (*ServerDetails, error) {
+	data := struct {
+		Response struct {
+			Server ServerDetails
+		}
+	}{}
+	err := s.client.post(context, "server/clone", &data, struct {
+		CloneServerParams
+		ServerID string `json:"serverid"`
+	}{params, serverID})
+	return &data.Response.Server, err
+}
+
 // Console returns connection details for server web console
 func (s *ServerService) Console(context context.Context, serverID string) (*ServerConsoleDetails, error) {
 	data := struct {
@@ -238,6 +283,19 @@ func (s *ServerService) Console(context context.Context, serverID string) (*Serv
 	return &data.Response.Console, err
 }
 
+// Costs returns the costs of a server
+func (s *ServerService) Costs(context context.Context, serverID string) (*ServerCosts, error) {
+	data := struct {
+		Response struct {
+			Costs ServerCosts
+		}
+	}{}
+	err := s.client.post(context, "server/costs", &data, struct {
+		ServerID string `json:"serverid"`
+	}{serverID})
+	return &data.Response.Costs, err
+}
+
 // Destroy deletes a server
 func (s *ServerService) Destroy(context context.Context, serverID string, params DestroyServerParams) error {
 	return s.client.post(context, "server/destroy", nil, struct {
@@ -264,6 +322,11 @@ func (s *ServerService) Edit(context context.Context, serverID string, params Ed
 			Server ServerDetails
 		}
 	}{}
+	if s.validator != nil {
+		if err := s.validator.validateEdit(context, s, serverID, params); err != nil {
+			return nil, err
+		}
+	}
 	err := s.client.post(context, "server/edit", &data, struct {
 		EditServerParams
 		ServerID string `json:"serverid"`
@@ -271,6 +334,18 @@ func (s *ServerService) Edit(context context.Context, serverID string, params Ed
 	return &data.Response.Server, err
 }
 
+// EstimatedCost returns the estimated costs of a new server, see also
+// EstimateServer
+func (s *ServerService) EstimatedCost(context context.Context, params CreateServerParams) (*ServerCosts, error) {
+	data := struct {
+		Response struct {
+			EstimatedCost ServerCosts
+		}
+	}{}
+	err := s.client.post(context, "server/estimatedcost", &data, params)
+	return &data.Response.EstimatedCost, err
+}
+
 // List returns a list of servers
 func (s *ServerService) List(context context.Context) (*[]Server, error) {
 	data := struct {
@@ -346,11 +421,51 @@ func (s *ServerService) Templates(context context.Context) (*ServerPlatformTempl
 	return &data.Response.Templates, err
 }
 
+// AllowedArguments returns the allowed values of the server parameters per
+// platform.
+func (s *ServerService) AllowedArguments(context context.Context) (*ServerAllowedArguments, error) {
+	data := struct {
+		Response struct {
+			Argumentslist ServerAllowedArguments
+		}
+	}{}
+	err := s.client.post(context, "server/allowedarguments", &data, nil)
+	return &data.Response.Argumentslist, err
+}
+
+// ResourceUsage returns a time series of the usage of a resource of a
+// server, e.g. the cpu usage per minute
+func (s *ServerService) ResourceUsage(context context.Context, serverID string, params ServerResourceUsageParams) (*ServerResourceUsage, error) {
+	data := struct {
+		Response struct {
+			Usage ServerResourceUsage
+		}
+	}{}
+	err := s.client.post(context, "server/resourceusage", &data, struct {
+		ServerResourceUsageParams
+		ServerID string `json:"serverid"`
+	}{params, serverID})
+	return &data.Response.Usage, err
+}
+
 // Start turns on a server
 func (s *ServerService) Start(context context.Context, serverID string) error {
 	return s.client.post(context, "server/start", nil, map[string]string{"serverid": serverID})
 }
 
+// Status returns the current state and resource usage of a server
+func (s *ServerService) Status(context context.Context, serverID string) (*ServerStatus, error) {
+	data := struct {
+		Response struct {
+			Server ServerStatus
+		}
+	}{}
+	err := s.client.post(context, "server/status", &data, struct {
+		ServerID string `json:"serverid"`
+	}{serverID})
+	return &data.Response.Server, err
+}
+
 // Stop turns off a server
 func (s *ServerService) Stop(context context.Context, serverID string, params StopServerParams) error {
 	return s.client.post(context, "server/stop", nil, struct {
@@ -0,0 +1,239 @@
+package glesys
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Resources of ServerService.ResourceUsage.
+const (
+	ResourceCPU     = "cpuusage"
+	ResourceMemory  = "memoryusage"
+	ResourceDisk    = "diskusage"
+	ResourceNetwork = "transfer"
+)
+
+// Resolutions of ServerService.ResourceUsage.
+const (
+	ResolutionMinute = "minute"
+	ResolutionHour   = "hour"
+	ResolutionDay    = "day"
+)
+
+// ServerStatus is the current state and resource usage of a server.
+type ServerStatus struct {
+	State    string
+	CPU      ServerStatusUsage
+	Memory   ServerStatusUsage
+	Disk     ServerStatusUsage
+	Uptime   time.Duration
+	Warnings []string
+}
+
+// ServerStatusUsage is the current usage of a resource.
+type ServerStatusUsage struct {
+	Usage float64 `json:"usage"`
+	Max   float64 `json:"max"`
+	Unit  string  `json:"unit"`
+}
+
+// Percent returns the usage in percent of the max, or 0 if the max is
+// unknown.
+func (u ServerStatusUsage) Percent() float64 {
+	if u.Max == 0 {
+		return 0
+	}
+	return u.Usage / u.Max * 100
+}
+
+// serverStatusJSON is the encoding of ServerStatus used by the API.
+type serverStatusJSON struct {
+	State    string             `json:"state"`
+	CPU      ServerStatusUsage  `json:"cpu"`
+	Memory   ServerStatusUsage  `json:"memory"`
+	Disk     ServerStatusUsage  `json:"disk"`
+	Uptime   serverStatusUptime `json:"uptime"`
+	Warnings []string           `json:"warnings"`
+}
+
+type serverStatusUptime struct {
+	Current float64 `json:"current"`
+	Unit    string  `json:"unit"`
+}
+
+// MarshalJSON encodes the status like the API, with the uptime in seconds.
+func (s ServerStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(serverStatusJSON{
+		State:    s.State,
+		CPU:      s.CPU,
+		Memory:   s.Memory,
+		Disk:     s.Disk,
+		Uptime:   serverStatusUptime{Current: s.Uptime.Seconds(), Unit: "s"},
+		Warnings: s.Warnings,
+	})
+}
+
+// UnmarshalJSON decodes the uptime, which is given in seconds, as a
+// time.Duration.
+func (s *ServerStatus) UnmarshalJSON(data []byte) error {
+	var raw serverStatusJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*s = ServerStatus{
+		State:    raw.State,
+		CPU:      raw.CPU,
+		Memory:   raw.Memory,
+		Disk:     raw.Disk,
+		Uptime:   time.Duration(raw.Uptime.Current * float64(time.Second)),
+		Warnings: raw.Warnings,
+	}
+	return nil
+}
+
+// ServerResourceUsageParams is used when fetching the resource usage of a
+// server.
+type ServerResourceUsageParams struct {
+	// Resource is one of ResourceCPU, ResourceMemory, ResourceDisk and
+	// ResourceNetwork.
+	Resource string `json:"resource"`
+	// Resolution is one of ResolutionMinute, ResolutionHour and ResolutionDay.
+	Resolution string `json:"resolution"`
+}
+
+// ServerResourceUsage is a time series of the usage of a resource.
+type ServerResourceUsage struct {
+	Resource   string
+	Resolution string
+	Unit       string
+	Entries    []ServerResourceUsageEntry
+}
+
+// ServerResourceUsageEntry is the usage of a resource at a point in time.
+type ServerResourceUsageEntry struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// Max returns the highest value, or 0 if there are no entries.
+func (u *ServerResourceUsage) Max() float64 {
+	var highest float64
+	for i, entry := range u.Entries {
+		if i == 0 || entry.Value > highest {
+			highest = entry.Value
+		}
+	}
+	return highest
+}
+
+// Average returns the average value, or 0 if there are no entries.
+func (u *ServerResourceUsage) Average() float64 {
+	if len(u.Entries) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, entry := range u.Entries {
+		sum += entry.Value
+	}
+	return sum / float64(len(u.Entries))
+}
+
+// Since returns the entries at or after t.
+func (u *ServerResourceUsage) Since(t time.Time) []ServerResourceUsageEntry {
+	var entries []ServerResourceUsageEntry
+	for _, entry := range u.Entries {
+		if !entry.Timestamp.Before(t) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// serverResourceUsageJSON is the encoding of ServerResourceUsage used by
+// the API.
+type serverResourceUsageJSON struct {
+	Info struct {
+		Resource   string `json:"resource"`
+		Resolution string `json:"resolution"`
+		Unit       string `json:"unit"`
+	} `json:"info"`
+	Entries []ServerResourceUsageEntry `json:"entries"`
+}
+
+// MarshalJSON encodes the usage like the API.
+func (u ServerResourceUsage) MarshalJSON() ([]byte, error) {
+	var raw serverResourceUsageJSON
+	raw.Info.Resource = u.Resource
+	raw.Info.Resolution = u.Resolution
+	raw.Info.Unit = u.Unit
+	raw.Entries = u.Entries
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON decodes the info and entries of the usage.
+func (u *ServerResourceUsage) UnmarshalJSON(data []byte) error {
+	var raw serverResourceUsageJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*u = ServerResourceUsage{
+		Resource:   raw.Info.Resource,
+		Resolution: raw.Info.Resolution,
+		Unit:       raw.Info.Unit,
+		Entries:    raw.Entries,
+	}
+	return nil
+}
+
+// timestampLayouts are the layouts of timestamps used by the API. Timestamps
+// without a time zone are in UTC.
+var timestampLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// serverResourceUsageEntryJSON is the encoding of ServerResourceUsageEntry
+// used by the API.
+type serverResourceUsageEntryJSON struct {
+	Timestamp string  `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+// MarshalJSON encodes the entry like the API, with the timestamp in RFC 3339
+// format.
+func (e ServerResourceUsageEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(serverResourceUsageEntryJSON{
+		Timestamp: e.Timestamp.Format(time.RFC3339),
+		Value:     e.Value,
+	})
+}
+
+// UnmarshalJSON decodes the timestamp as a time.Time.
+func (e *ServerResourceUsageEntry) UnmarshalJSON(data []byte) error {
+	var raw serverResourceUsageEntryJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	timestamp, err := parseTimestamp(raw.Timestamp)
+	if err != nil {
+		return err
+	}
+	*e = ServerResourceUsageEntry{Timestamp: timestamp, Value: raw.Value}
+	return nil
+}
+
+func parseTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("glesys: invalid timestamp %q", s)
+}
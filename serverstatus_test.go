@@ -0,0 +1,101 @@
+package glesys
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServersStatus(t *testing.T) {
+	c := &mockClient{body: `{"response": {"server": {
+		"state": "running",
+		"cpu": {"usage": 0.5, "max": 2, "unit": "cores"},
+		"memory": {"usage": 512, "max": 2048, "unit": "MB"},
+		"disk": {"usage": 5, "max": 20, "unit": "GB"},
+		"uptime": {"current": 90061, "unit": "s"},
+		"warnings": []
+	}}}`}
+	s := ServerService{client: c}
+
+	status, err := s.Status(context.Background(), "kvm123456")
+	assert.NoError(t, err)
+	assert.Equal(t, "POST", c.lastMethod, "method used is correct")
+	assert.Equal(t, "server/status", c.lastPath, "path used is correct")
+	assert.Equal(t, "running", status.State)
+	assert.Equal(t, 25.0, status.CPU.Percent(), "cpu usage in percent is correct")
+	assert.Equal(t, "MB", status.Memory.Unit)
+	assert.Equal(t, 25*time.Hour+time.Minute+time.Second, status.Uptime, "uptime is decoded as a duration")
+	assert.Equal(t, 0.0, ServerStatusUsage{Usage: 1}.Percent(), "percent is 0 without max")
+}
+
+func TestServersResourceUsage(t *testing.T) {
+	c := &mockClient{body: `{"response": {"usage": {
+		"info": {"resource": "cpuusage", "resolution": "minute", "unit": "%"},
+		"entries": [
+			{"timestamp": "2024-01-01T12:00:00+01:00", "value": 10},
+			{"timestamp": "2024-01-01T11:01:00", "value": 40},
+			{"timestamp": "2024-01-01 11:02:00", "value": 25}
+		]
+	}}}`}
+	s := ServerService{client: c}
+
+	usage, err := s.ResourceUsage(context.Background(), "kvm123456", ServerResourceUsageParams{Resource: ResourceCPU, Resolution: ResolutionMinute})
+	assert.NoError(t, err)
+	assert.Equal(t, "server/resourceusage", c.lastPath, "path used is correct")
+	assert.Equal(t, "cpuusage", usage.Resource)
+	assert.Equal(t, "%", usage.Unit)
+	assert.Len(t, usage.Entries, 3)
+	assert.True(t, usage.Entries[0].Timestamp.Equal(time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)), "timestamp with time zone is decoded")
+	assert.Equal(t, time.Date(2024, 1, 1, 11, 2, 0, 0, time.UTC), usage.Entries[2].Timestamp, "timestamp without time zone is in UTC")
+	assert.Equal(t, 40.0, usage.Max())
+	assert.Equal(t, 25.0, usage.Average())
+	assert.Len(t, usage.Since(time.Date(2024, 1, 1, 11, 1, 0, 0, time.UTC)), 2)
+}
+
+func TestServersResourceUsageInvalidTimestamp(t *testing.T) {
+	c := &mockClient{body: `{"response": {"usage": {"entries": [{"timestamp": "yesterday", "value": 1}]}}}`}
+	s := ServerService{client: c}
+
+	_, err := s.ResourceUsage(context.Background(), "kvm123456", ServerResourceUsageParams{Resource: ResourceCPU})
+	assert.EqualError(t, err, `glesys: invalid timestamp "yesterday"`)
+}
+
+func TestServerStatusJSONRoundTrip(t *testing.T) {
+	status := ServerStatus{
+		State:    "running",
+		CPU:      ServerStatusUsage{Usage: 0.5, Max: 2, Unit: "cores"},
+		Uptime:   90 * time.Second,
+		Warnings: []string{},
+	}
+
+	encoded, err := json.Marshal(status)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"state": "running", "cpu": {"usage": 0.5, "max": 2, "unit": "cores"},
+		"memory": {"usage": 0, "max": 0, "unit": ""}, "disk": {"usage": 0, "max": 0, "unit": ""},
+		"uptime": {"current": 90, "unit": "s"}, "warnings": []}`, string(encoded))
+
+	var decoded ServerStatus
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, status, decoded)
+}
+
+func TestServerResourceUsageJSONRoundTrip(t *testing.T) {
+	usage := ServerResourceUsage{
+		Resource:   ResourceCPU,
+		Resolution: ResolutionMinute,
+		Unit:       "%",
+		Entries:    []ServerResourceUsageEntry{{Timestamp: time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC), Value: 10}},
+	}
+
+	encoded, err := json.Marshal(usage)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"info": {"resource": "cpuusage", "resolution": "minute", "unit": "%"},
+		"entries": [{"timestamp": "2024-01-01T11:00:00Z", "value": 10}]}`, string(encoded))
+
+	var decoded ServerResourceUsage
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, usage, decoded)
+}
@@ -0,0 +1,224 @@
+package glesys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// OTPFunc returns a one-time password for a login. It is called for every
+// login of a Session, including re-authentications.
+type OTPFunc func(ctx context.Context) (string, error)
+
+// Session is a logged in user. It lists the organizations and projects of the
+// user and hands out clients for the projects which use the API key of the
+// session.
+//
+// The session logs in again when the API key is rejected, e.g. after it has
+// expired, and the failed call is retried once with the new key.
+type Session struct {
+	username string
+	password string
+	otp      OTPFunc
+	opts     []Option
+
+	// Login is used for the calls made by the session itself.
+	login *Login
+
+	mu      sync.Mutex
+	details *LoginDetailsResponse
+}
+
+// NewSession creates a session for the user. The otp function is called to
+// get a one-time password for every login and can be nil for users without
+// two-factor authentication. The options are used by the session and all
+// clients handed out by it. Call Login to log in.
+func NewSession(username, password string, otp OTPFunc, opts ...Option) (*Session, error) {
+	login, err := NewLoginWithOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{
+		username: username,
+		password: password,
+		otp:      otp,
+		opts:     opts,
+		login:    login,
+	}, nil
+}
+
+// Login logs in the user.
+func (s *Session) Login(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.doLogin(ctx)
+}
+
+// Details returns the details of the current login, or nil if the session
+// is not logged in.
+func (s *Session) Details() *LoginDetailsResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.details == nil {
+		return nil
+	}
+	details := *s.details
+	return &details
+}
+
+// Organizations lists the organizations of the user.
+func (s *Session) Organizations(ctx context.Context) (*[]UserOrganization, error) {
+	var organizations *[]UserOrganization
+	err := s.withLogin(ctx, func(login *Login) (err error) {
+		organizations, err = login.Users.ListOrganizations(ctx)
+		return err
+	})
+	return organizations, err
+}
+
+// Projects lists the projects of an organization.
+func (s *Session) Projects(ctx context.Context, organizationNumber string) (*[]CustomerProject, error) {
+	var projects *[]CustomerProject
+	err := s.withLogin(ctx, func(login *Login) (err error) {
+		projects, err = login.Users.ListCustomerProjects(ctx, organizationNumber)
+		return err
+	})
+	return projects, err
+}
+
+// Client returns a client for project, e.g. the Accountname of a
+// CustomerProject, which uses the API key of the session. The options in opts
+// are applied after the options of the session.
+func (s *Session) Client(project string, opts ...Option) (*Client, error) {
+	s.mu.Lock()
+	loggedIn := s.details != nil
+	s.mu.Unlock()
+	if !loggedIn {
+		return nil, errors.New("glesys: session is not logged in")
+	}
+
+	opts = append(append(append([]Option(nil), s.opts...), opts...), WithMiddleware(s.authenticate))
+	return NewClientWithOptions(project, "", opts...)
+}
+
+// doLogin logs in the user. s.mu must be held.
+func (s *Session) doLogin(ctx context.Context) error {
+	var otp string
+	if s.otp != nil {
+		var err error
+		if otp, err = s.otp(ctx); err != nil {
+			return fmt.Errorf("glesys: getting one-time password: %w", err)
+		}
+	}
+
+	login, err := NewLoginWithOptions(s.opts...)
+	if err != nil {
+		return err
+	}
+	details, err := login.Users.DoOTPLogin(ctx, s.username, s.password, otp)
+	if err != nil {
+		return err
+	}
+
+	login.Username = details.Username
+	login.APIKey = details.APIKey
+	login.Accounts = details.Accounts
+	login.Customers = details.Customers
+	s.login = login
+	s.details = details
+	return nil
+}
+
+// refresh logs in again unless the rejected API key has already been
+// replaced, e.g. by a concurrent call.
+func (s *Session) refresh(ctx context.Context, rejected string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.details != nil && s.details.APIKey != rejected {
+		return nil
+	}
+	return s.doLogin(ctx)
+}
+
+// current returns the login and API key of the session.
+func (s *Session) current() (*Login, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.details == nil {
+		return nil, "", errors.New("glesys: session is not logged in")
+	}
+	return s.login, s.details.APIKey, nil
+}
+
+// isRejectedKey returns true if err is a 401 response, which means that the
+// API key is no longer valid. Other errors matching ErrUnauthorized, such as
+// 403 responses, are not solved by logging in again.
+func isRejectedKey(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.hasCode(http.StatusUnauthorized)
+}
+
+// withLogin calls fn with the current login and retries once after logging
+// in again if the API key is rejected.
+func (s *Session) withLogin(ctx context.Context, fn func(*Login) error) error {
+	login, apiKey, err := s.current()
+	if err != nil {
+		return err
+	}
+
+	err = fn(login)
+	if !isRejectedKey(err) {
+		return err
+	}
+	if err := s.refresh(ctx, apiKey); err != nil {
+		return err
+	}
+	if login, _, err = s.current(); err != nil {
+		return err
+	}
+	return fn(login)
+}
+
+// authenticate is a Middleware which sets the credentials of the session on
+// all requests, except those using credentials from WithProject, and logs in
+// again when the API key is rejected.
+func (s *Session) authenticate(next Handler) Handler {
+	return func(request *Request) (*Response, error) {
+		ctx := request.HTTPRequest.Context()
+		if _, ok := ProjectFromContext(ctx); ok {
+			return next(request)
+		}
+
+		_, apiKey, err := s.current()
+		if err != nil {
+			return nil, err
+		}
+		request.HTTPRequest.SetBasicAuth(request.Project, apiKey)
+
+		retry, err := rewind(request.HTTPRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := next(request)
+		if !isRejectedKey(err) {
+			return response, err
+		}
+		if err := s.refresh(ctx, apiKey); err != nil {
+			return response, fmt.Errorf("glesys: logging in again: %w", err)
+		}
+		if _, apiKey, err = s.current(); err != nil {
+			return response, err
+		}
+
+		retry.SetBasicAuth(request.Project, apiKey)
+		request.HTTPRequest = retry
+		return next(request)
+	}
+}
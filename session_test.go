@@ -0,0 +1,141 @@
+package glesys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newSessionTestServer returns a server which hands out a new API key for
+// every login and only accepts the latest key.
+func newSessionTestServer(t *testing.T) (*httptest.Server, *[]string) {
+	var logins []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&params)
+
+		if r.URL.Path == "/user/login" {
+			otp, _ := params["otp"].(string)
+			logins = append(logins, otp)
+			fmt.Fprintf(w, `{ "response": { "login": { "username": "alice@example.com", "apikey": "key-%d" } } }`, len(logins))
+			return
+		}
+
+		username, password, _ := r.BasicAuth()
+		if password != fmt.Sprintf("key-%d", len(logins)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{ "response": { "status": { "code": 401, "text": "Invalid API key" } } }`)
+			return
+		}
+
+		if r.URL.Path == "/server/destroy" || params["organizationnumber"] == "forbidden" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{ "response": { "status": { "code": 403, "text": "Permission denied" } } }`)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/user/listorganizations":
+			fmt.Fprint(w, `{ "response": { "organizations": [{ "id": 1337 }] } }`)
+		case "/customer/listprojects":
+			fmt.Fprint(w, `{ "response": { "projects": [{ "accountname": "cl12345" }] } }`)
+		case "/server/create":
+			assert.Equal(t, "cl12345", username, "project is used as username")
+			fmt.Fprintf(w, `{ "response": { "server": { "serverid": "kvm12345", "hostname": "%v" } } }`, params["hostname"])
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &logins
+}
+
+func TestSession(t *testing.T) {
+	server, logins := newSessionTestServer(t)
+	ctx := context.Background()
+
+	otps := 0
+	session, err := NewSession("alice@example.com", "password", func(ctx context.Context) (string, error) {
+		otps++
+		return fmt.Sprintf("otp-%d", otps), nil
+	}, WithBaseURL(server.URL))
+	assert.NoError(t, err)
+
+	_, err = session.Client("cl12345")
+	assert.Error(t, err, "session has to be logged in")
+
+	assert.NoError(t, session.Login(ctx))
+	assert.Equal(t, "key-1", session.Details().APIKey)
+	assert.Equal(t, []string{"otp-1"}, *logins, "one-time password is used")
+
+	organizations, err := session.Organizations(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1337, (*organizations)[0].ID)
+
+	projects, err := session.Projects(ctx, "1337")
+	assert.NoError(t, err)
+
+	client, err := session.Client((*projects)[0].Accountname)
+	assert.NoError(t, err)
+	created, err := client.Servers.Create(ctx, CreateServerParams{Hostname: "web-01"})
+	assert.NoError(t, err)
+	assert.Equal(t, "kvm12345", created.ID, "client uses the session key")
+	assert.Len(t, *logins, 1)
+}
+
+func TestSessionReauthenticates(t *testing.T) {
+	server, logins := newSessionTestServer(t)
+	ctx := context.Background()
+
+	session, _ := NewSession("alice@example.com", "password", nil, WithBaseURL(server.URL))
+	assert.NoError(t, session.Login(ctx))
+	client, _ := session.Client("cl12345")
+
+	// Another login invalidates the key of the session.
+	*logins = append(*logins, "")
+
+	created, err := client.Servers.Create(ctx, CreateServerParams{Hostname: "web-01"})
+	assert.NoError(t, err, "call is retried after logging in again")
+	assert.Equal(t, "web-01", created.Hostname, "body is sent again")
+	assert.Equal(t, "key-3", session.Details().APIKey, "session key is replaced")
+
+	*logins = append(*logins, "")
+
+	organizations, err := session.Organizations(ctx)
+	assert.NoError(t, err, "session calls are retried after logging in again")
+	assert.Len(t, *organizations, 1)
+	assert.Len(t, *logins, 5)
+
+	_, err = client.Servers.Create(WithProject(ctx, "cl12345", "wrong-key"), CreateServerParams{Hostname: "web-01"})
+	assert.ErrorIs(t, err, ErrUnauthorized, "credentials from the context are not replaced")
+	assert.Len(t, *logins, 5)
+}
+
+func TestSessionDoesNotReauthenticateWhenForbidden(t *testing.T) {
+	server, logins := newSessionTestServer(t)
+	ctx := context.Background()
+
+	otps := 0
+	session, _ := NewSession("alice@example.com", "password", func(ctx context.Context) (string, error) {
+		otps++
+		return fmt.Sprintf("otp-%d", otps), nil
+	}, WithBaseURL(server.URL))
+	assert.NoError(t, session.Login(ctx))
+	client, _ := session.Client("cl12345")
+
+	err := client.Servers.Destroy(ctx, "kvm12345", DestroyServerParams{})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	var apiErr *APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, 403, apiErr.StatusCode, "error of the call is returned")
+	}
+
+	_, err = session.Projects(ctx, "forbidden")
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	assert.Equal(t, []string{"otp-1"}, *logins, "403 responses do not log in again")
+	assert.Equal(t, 1, otps)
+}
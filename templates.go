@@ -0,0 +1,233 @@
+package glesys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Bootstrap methods of templates.
+const (
+	BootstrapCloudInit    = "CLOUD_INIT"
+	BootstrapDeployScript = "DEPLOY_SCRIPT"
+)
+
+// ErrTemplateNotFound is returned when no template matches a TemplateQuery.
+var ErrTemplateNotFound = errors.New("glesys: template not found")
+
+// TemplateQuery selects templates. Empty fields match all templates.
+type TemplateQuery struct {
+	// Platform is the platform, e.g. "KVM" or "VMware".
+	Platform string
+	// OS is the operating system, e.g. "linux" or "windows", or the
+	// distribution, e.g. "ubuntu" or "debian".
+	OS string
+	// Name is a pattern for the name, e.g. "Debian 12*". The syntax is that
+	// of path.Match and matching is case insensitive.
+	Name string
+	// LTS selects long-term support releases, i.e. templates with LTS or
+	// LTSC in the name and Ubuntu releases from April of even years.
+	LTS bool
+}
+
+// LatestUbuntuLTS selects the latest Ubuntu LTS release when used with
+// ServerPlatformTemplates.Latest.
+var LatestUbuntuLTS = TemplateQuery{OS: "ubuntu", LTS: true}
+
+// Matches reports whether the template is selected by q.
+func (q TemplateQuery) Matches(template ServerPlatformTemplateDetails) bool {
+	if q.Platform != "" && !strings.EqualFold(q.Platform, template.Platform) {
+		return false
+	}
+	if q.OS != "" && !strings.EqualFold(q.OS, template.OS) && !strings.EqualFold(q.OS, template.Distribution()) {
+		return false
+	}
+	if q.Name != "" {
+		if ok, _ := path.Match(strings.ToLower(q.Name), strings.ToLower(template.Name)); !ok {
+			return false
+		}
+	}
+	if q.LTS && !template.IsLTS() {
+		return false
+	}
+	return true
+}
+
+// Validate returns an error if the name pattern is malformed.
+func (q TemplateQuery) Validate() error {
+	if _, err := path.Match(q.Name, ""); err != nil {
+		return fmt.Errorf("glesys: invalid template name pattern %q: %w", q.Name, err)
+	}
+	return nil
+}
+
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)*`)
+
+// Distribution returns the first word of the name in lower case, e.g.
+// "ubuntu" for "Ubuntu 24.04 LTS (Noble Numbat)".
+func (t ServerPlatformTemplateDetails) Distribution() string {
+	fields := strings.Fields(t.Name)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToLower(fields[0])
+}
+
+// Version returns the first version number of the name, e.g. [24 4] for
+// "Ubuntu 24.04 LTS (Noble Numbat)", or nil if the name has none.
+func (t ServerPlatformTemplateDetails) Version() []int {
+	match := versionPattern.FindString(t.Name)
+	if match == "" {
+		return nil
+	}
+	var version []int
+	for _, part := range strings.Split(match, ".") {
+		n, _ := strconv.Atoi(part)
+		version = append(version, n)
+	}
+	return version
+}
+
+// IsLTS reports whether the template is a long-term support release.
+func (t ServerPlatformTemplateDetails) IsLTS() bool {
+	for _, field := range strings.Fields(strings.ToUpper(t.Name)) {
+		if field == "LTS" || field == "LTSC" {
+			return true
+		}
+	}
+	version := t.Version()
+	return t.Distribution() == "ubuntu" && len(version) >= 2 && version[0]%2 == 0 && version[1] == 4
+}
+
+// All returns the templates of all platforms.
+func (t *ServerPlatformTemplates) All() []ServerPlatformTemplateDetails {
+	all := append([]ServerPlatformTemplateDetails(nil), t.KVM...)
+	return append(all, t.VMware...)
+}
+
+// Find returns the templates selected by query, the latest version first.
+func (t *ServerPlatformTemplates) Find(query TemplateQuery) []ServerPlatformTemplateDetails {
+	var found []ServerPlatformTemplateDetails
+	for _, template := range t.All() {
+		if query.Matches(template) {
+			found = append(found, template)
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return compareVersions(found[i].Version(), found[j].Version()) > 0
+	})
+	return found
+}
+
+// Latest returns the template with the highest version selected by query,
+// e.g. LatestUbuntuLTS. ErrTemplateNotFound is returned if no template is
+// selected.
+func (t *ServerPlatformTemplates) Latest(query TemplateQuery) (*ServerPlatformTemplateDetails, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	found := t.Find(query)
+	if len(found) == 0 {
+		return nil, fmt.Errorf("%w: %+v", ErrTemplateNotFound, query)
+	}
+	return &found[0], nil
+}
+
+// Lookup returns the template with the name on platform, which is how
+// CreateServerParams refers to templates.
+func (t *ServerPlatformTemplates) Lookup(platform, name string) (*ServerPlatformTemplateDetails, error) {
+	for _, template := range t.All() {
+		if strings.EqualFold(template.Platform, platform) && template.Name == name {
+			return &template, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q on %s", ErrTemplateNotFound, name, platform)
+}
+
+// compareVersions compares versions part by part, missing parts are
+// considered lower.
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		switch {
+		case i >= len(a):
+			return -1
+		case i >= len(b):
+			return 1
+		case a[i] != b[i]:
+			return a[i] - b[i]
+		}
+	}
+	return 0
+}
+
+// ResolveTemplate returns the template with the highest version selected by
+// query using the templates of the API.
+func ResolveTemplate(ctx context.Context, servers ServerAPI, query TemplateQuery) (*ServerPlatformTemplateDetails, error) {
+	templates, err := servers.Templates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return templates.Latest(query)
+}
+
+// WithTemplate sets the Template and Platform parameters of
+// CreateServerParams to those of template.
+func (p CreateServerParams) WithTemplate(template ServerPlatformTemplateDetails) CreateServerParams {
+	p.Template = template.Name
+	p.Platform = template.Platform
+	return p
+}
+
+// ValidateForTemplate checks the parameters against the requirements of
+// template: the platform, the minimum disk and memory sizes, and that
+// CloudConfig and Users are only used with templates bootstrapped by
+// cloud-init. A *ParamsError is returned if the parameters are invalid.
+func (p CreateServerParams) ValidateForTemplate(template ServerPlatformTemplateDetails) error {
+	var errs []FieldError
+	if p.Template != "" && p.Template != template.Name {
+		errs = append(errs, FieldError{"Template", fmt.Sprintf("is %q, not %q", p.Template, template.Name)})
+	}
+	if p.Platform != "" && !strings.EqualFold(p.Platform, template.Platform) {
+		errs = append(errs, FieldError{"Platform", fmt.Sprintf("template %q is not available on %s", template.Name, p.Platform)})
+	}
+	if p.Storage < template.MinDiskSize {
+		errs = append(errs, FieldError{"Storage", fmt.Sprintf("must be at least %d for template %q", template.MinDiskSize, template.Name)})
+	}
+	if p.Memory < template.MinMemSize {
+		errs = append(errs, FieldError{"Memory", fmt.Sprintf("must be at least %d for template %q", template.MinMemSize, template.Name)})
+	}
+
+	if !strings.EqualFold(template.BootstrapMethod, BootstrapCloudInit) {
+		message := fmt.Sprintf("is not supported by template %q with bootstrap method %s", template.Name, template.BootstrapMethod)
+		if p.CloudConfig != "" {
+			errs = append(errs, FieldError{"CloudConfig", message})
+		}
+		if len(p.CloudConfigParams) > 0 {
+			errs = append(errs, FieldError{"CloudConfigParams", message})
+		}
+		if len(p.Users) > 0 {
+			errs = append(errs, FieldError{"Users", message})
+		}
+	}
+	return paramsError(errs)
+}
+
+// ValidateCreateServerParams looks up the template of params using the API
+// and checks the parameters against it, see
+// CreateServerParams.ValidateForTemplate.
+func ValidateCreateServerParams(ctx context.Context, servers ServerAPI, params CreateServerParams) error {
+	templates, err := servers.Templates(ctx)
+	if err != nil {
+		return err
+	}
+	template, err := templates.Lookup(params.Platform, params.Template)
+	if err != nil {
+		return paramsError([]FieldError{{"Template", fmt.Sprintf("template %q is not available on %s", params.Template, params.Platform)}})
+	}
+	return params.ValidateForTemplate(*template)
+}
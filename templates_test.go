@@ -0,0 +1,129 @@
+package glesys
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testTemplates() *ServerPlatformTemplates {
+	return &ServerPlatformTemplates{
+		KVM: []ServerPlatformTemplateDetails{
+			{Name: "Debian 11 (Bullseye)", OS: "linux", Platform: "KVM", MinDiskSize: 5, MinMemSize: 512, BootstrapMethod: "CLOUD_INIT"},
+			{Name: "Debian 12 (Bookworm)", OS: "linux", Platform: "KVM", MinDiskSize: 5, MinMemSize: 512, BootstrapMethod: "CLOUD_INIT"},
+			{Name: "Ubuntu 22.04 LTS (Jammy Jellyfish)", OS: "linux", Platform: "KVM", MinDiskSize: 5, MinMemSize: 512, BootstrapMethod: "CLOUD_INIT"},
+			{Name: "Ubuntu 24.04 (Noble Numbat)", OS: "linux", Platform: "KVM", MinDiskSize: 5, MinMemSize: 512, BootstrapMethod: "CLOUD_INIT"},
+			{Name: "Ubuntu 24.10 (Oracular Oriole)", OS: "linux", Platform: "KVM", MinDiskSize: 5, MinMemSize: 512, BootstrapMethod: "CLOUD_INIT"},
+		},
+		VMware: []ServerPlatformTemplateDetails{
+			{Name: "Ubuntu 22.04 LTS", OS: "linux", Platform: "VMware", MinDiskSize: 5, MinMemSize: 512, BootstrapMethod: "CLOUD_INIT"},
+			{Name: "Windows Server 2022 Standard LTSC", OS: "windows", Platform: "VMware", MinDiskSize: 30, MinMemSize: 1024, BootstrapMethod: "DEPLOY_SCRIPT"},
+		},
+	}
+}
+
+func TestTemplatesFind(t *testing.T) {
+	templates := testTemplates()
+
+	assert.Len(t, templates.All(), 7)
+	assert.Len(t, templates.Find(TemplateQuery{Platform: "vmware"}), 2, "platform is matched case insensitively")
+	assert.Len(t, templates.Find(TemplateQuery{OS: "windows"}), 1, "operating system is matched")
+	assert.Len(t, templates.Find(TemplateQuery{OS: "Debian"}), 2, "distribution is matched")
+
+	found := templates.Find(TemplateQuery{Name: "ubuntu 2*", Platform: "KVM"})
+	assert.Equal(t, "Ubuntu 24.10 (Oracular Oriole)", found[0].Name, "latest version is first")
+	assert.Equal(t, "Ubuntu 22.04 LTS (Jammy Jellyfish)", found[2].Name)
+
+	template, err := templates.Latest(LatestUbuntuLTS)
+	assert.NoError(t, err)
+	assert.Equal(t, "Ubuntu 24.04 (Noble Numbat)", template.Name, "latest Ubuntu LTS is found")
+
+	template, err = templates.Latest(TemplateQuery{OS: "debian"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Debian 12 (Bookworm)", template.Name)
+
+	template, _ = templates.Latest(TemplateQuery{Platform: "VMware", LTS: true, OS: "windows"})
+	assert.Equal(t, "Windows Server 2022 Standard LTSC", template.Name, "LTSC is long-term support")
+
+	_, err = templates.Latest(TemplateQuery{OS: "freebsd"})
+	assert.ErrorIs(t, err, ErrTemplateNotFound)
+
+	_, err = templates.Latest(TemplateQuery{Name: "[debian"})
+	assert.Error(t, err, "malformed pattern returns an error")
+
+	template, err = templates.Lookup("kvm", "Debian 12 (Bookworm)")
+	assert.NoError(t, err)
+	assert.Equal(t, "KVM", template.Platform)
+	_, err = templates.Lookup("VMware", "Debian 12 (Bookworm)")
+	assert.ErrorIs(t, err, ErrTemplateNotFound)
+}
+
+func TestTemplateVersion(t *testing.T) {
+	assert.Equal(t, []int{24, 4}, ServerPlatformTemplateDetails{Name: "Ubuntu 24.04 LTS"}.Version())
+	assert.Nil(t, ServerPlatformTemplateDetails{Name: "Custom"}.Version())
+	assert.Equal(t, "almalinux", ServerPlatformTemplateDetails{Name: "AlmaLinux 9"}.Distribution())
+	assert.False(t, ServerPlatformTemplateDetails{Name: "Ubuntu 23.04"}.IsLTS(), "odd years are not LTS")
+}
+
+func TestResolveTemplate(t *testing.T) {
+	body, _ := json.Marshal(map[string]any{"response": map[string]any{"templates": testTemplates()}})
+	s := &ServerService{client: &mockClient{body: string(body)}}
+
+	template, err := ResolveTemplate(context.Background(), s, LatestUbuntuLTS)
+	assert.NoError(t, err)
+	assert.Equal(t, "Ubuntu 24.04 (Noble Numbat)", template.Name)
+
+	params := CreateServerParams{Hostname: "web-01"}.WithDefaults().WithTemplate(*template)
+	assert.Equal(t, "Ubuntu 24.04 (Noble Numbat)", params.Template)
+	assert.Equal(t, "KVM", params.Platform)
+}
+
+func TestCreateServerParamsValidateForTemplate(t *testing.T) {
+	templates := testTemplates()
+	debian, _ := templates.Lookup("KVM", "Debian 12 (Bookworm)")
+	windows, _ := templates.Lookup("VMware", "Windows Server 2022 Standard LTSC")
+
+	params := CreateServerParams{Hostname: "web-01"}.WithDefaults().WithTemplate(*debian).WithUser("alice", nil, "secret")
+	params.CloudConfig = "#cloud-config\n"
+	assert.NoError(t, params.ValidateForTemplate(*debian))
+
+	params.Storage = 1
+	params.Memory = 256
+	err := params.ValidateForTemplate(*debian)
+	assert.ErrorIs(t, err, ErrValidation)
+	var perr *ParamsError
+	assert.True(t, errors.As(err, &perr))
+	assert.Len(t, perr.Errors, 2)
+	storage, ok := perr.Field("Storage")
+	assert.True(t, ok)
+	assert.Equal(t, `must be at least 5 for template "Debian 12 (Bookworm)"`, storage.Message)
+
+	params = CreateServerParams{Hostname: "web-01", Storage: 30, Memory: 1024}.WithTemplate(*windows).WithUser("alice", nil, "secret")
+	params.CloudConfig = "#cloud-config\n"
+	err = params.ValidateForTemplate(*windows)
+	assert.True(t, errors.As(err, &perr))
+	_, ok = perr.Field("CloudConfig")
+	assert.True(t, ok, "cloud-config requires cloud-init")
+	_, ok = perr.Field("Users")
+	assert.True(t, ok, "users require cloud-init")
+
+	params.Platform = "KVM"
+	err = params.ValidateForTemplate(*windows)
+	assert.Contains(t, err.Error(), `Platform: template "Windows Server 2022 Standard LTSC" is not available on KVM`)
+}
+
+func TestValidateCreateServerParams(t *testing.T) {
+	body, _ := json.Marshal(map[string]any{"response": map[string]any{"templates": testTemplates()}})
+	s := &ServerService{client: &mockClient{body: string(body)}}
+
+	params := CreateServerParams{Hostname: "web-01"}.WithDefaults()
+	assert.NoError(t, ValidateCreateServerParams(context.Background(), s, params), "default parameters are valid")
+
+	params.Template = "Debian 10"
+	err := ValidateCreateServerParams(context.Background(), s, params)
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Contains(t, err.Error(), `Template: template "Debian 10" is not available on KVM`)
+}
@@ -0,0 +1,84 @@
+package glesys
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+)
+
+// TOTP generates time-based one-time passwords according to RFC 6238, e.g.
+// for logins of users with two-factor authentication.
+type TOTP struct {
+	// Secret is the shared secret.
+	Secret []byte
+	// Period is the time step. Defaults to 30 seconds.
+	Period time.Duration
+	// Digits is the length of the codes. Defaults to 6.
+	Digits int
+	// Algorithm is the hash function used for the HMAC, e.g. sha256.New.
+	// Defaults to sha1.New.
+	Algorithm func() hash.Hash
+}
+
+// NewTOTP creates a TOTP with default settings from a base32 encoded secret,
+// as shown when setting up two-factor authentication. Spaces, padding and
+// lower case letters are accepted.
+func NewTOTP(secret string) (*TOTP, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil {
+		return nil, fmt.Errorf("glesys: invalid TOTP secret: %w", err)
+	}
+	return &TOTP{Secret: key}, nil
+}
+
+// Code returns the code for the time step of at.
+func (t *TOTP) Code(at time.Time) string {
+	period := t.Period
+	if period <= 0 {
+		period = 30 * time.Second
+	}
+	digits := t.Digits
+	if digits <= 0 {
+		digits = 6
+	}
+	algorithm := t.Algorithm
+	if algorithm == nil {
+		algorithm = sha1.New
+	}
+
+	step := int64(period / time.Second)
+	if step < 1 {
+		step = 1
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(at.Unix()/step))
+
+	mac := hmac.New(algorithm, t.Secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff
+
+	modulo := uint64(1)
+	for i := 0; i < digits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, uint64(value)%modulo)
+}
+
+// OTPFunc returns an OTPFunc generating the code for the current time, e.g.
+// to be used with NewSession.
+func (t *TOTP) OTPFunc() OTPFunc {
+	return func(ctx context.Context) (string, error) {
+		return t.Code(time.Now()), nil
+	}
+}
@@ -0,0 +1,92 @@
+package glesys
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/json"
+	"hash"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOTPRFC6238Vectors(t *testing.T) {
+	secrets := map[string][]byte{
+		"SHA1":   []byte("12345678901234567890"),
+		"SHA256": []byte("12345678901234567890123456789012"),
+		"SHA512": []byte("1234567890123456789012345678901234567890123456789012345678901234"),
+	}
+	algorithms := map[string]func() hash.Hash{"SHA1": sha1.New, "SHA256": sha256.New, "SHA512": sha512.New}
+
+	vectors := []struct {
+		time      int64
+		algorithm string
+		code      string
+	}{
+		{59, "SHA1", "94287082"},
+		{59, "SHA256", "46119246"},
+		{59, "SHA512", "90693936"},
+		{1111111109, "SHA1", "07081804"},
+		{1111111109, "SHA256", "68084774"},
+		{1111111109, "SHA512", "25091201"},
+		{1111111111, "SHA1", "14050471"},
+		{1111111111, "SHA256", "67062674"},
+		{1111111111, "SHA512", "99943326"},
+		{1234567890, "SHA1", "89005924"},
+		{1234567890, "SHA256", "91819424"},
+		{1234567890, "SHA512", "93441116"},
+		{2000000000, "SHA1", "69279037"},
+		{2000000000, "SHA256", "90698825"},
+		{2000000000, "SHA512", "38618901"},
+		{20000000000, "SHA1", "65353130"},
+		{20000000000, "SHA256", "77737706"},
+		{20000000000, "SHA512", "47863826"},
+	}
+
+	for _, v := range vectors {
+		totp, err := NewTOTP(base32.StdEncoding.EncodeToString(secrets[v.algorithm]))
+		assert.NoError(t, err)
+		totp.Digits = 8
+		totp.Algorithm = algorithms[v.algorithm]
+
+		assert.Equal(t, v.code, totp.Code(time.Unix(v.time, 0)), "code for %d using %s is correct", v.time, v.algorithm)
+	}
+}
+
+func TestTOTPDefaults(t *testing.T) {
+	totp, err := NewTOTP("gezd gnbv gy3t qojq gezd gnbv gy3t qojq")
+	assert.NoError(t, err, "spaces, lower case and missing padding are accepted")
+	assert.Equal(t, []byte("12345678901234567890"), totp.Secret)
+	assert.Equal(t, "287082", totp.Code(time.Unix(59, 0)), "six digits are used by default")
+	assert.Equal(t, totp.Code(time.Unix(30, 0)), totp.Code(time.Unix(59, 0)), "codes change every 30 seconds")
+
+	totp.Period = 60 * time.Second
+	assert.Equal(t, totp.Code(time.Unix(0, 0)), totp.Code(time.Unix(59, 0)), "period is used")
+
+	_, err = NewTOTP("not base32!")
+	assert.Error(t, err)
+}
+
+func TestUserLoginWithTOTPSecret(t *testing.T) {
+	c := &mockHTTPClient{body: `{ "response": { "login": { "username": "alice@example.com", "apikey": "abc-123-xyz" } } }`, statusCode: 200}
+	login, _ := NewLoginWithOptions()
+	login.httpClient = c
+
+	before := time.Now()
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	details, err := login.Users.LoginWithTOTPSecret(context.Background(), "alice@example.com", "password", secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc-123-xyz", details.APIKey)
+
+	var params LoginParams
+	json.NewDecoder(c.lastRequest.Body).Decode(&params)
+	totp, _ := NewTOTP(secret)
+	assert.Contains(t, []string{totp.Code(before), totp.Code(time.Now())}, params.Otp, "current code is used")
+
+	_, err = login.Users.LoginWithTOTPSecret(context.Background(), "alice@example.com", "password", "not base32!")
+	assert.Error(t, err, "invalid secret returns an error")
+}
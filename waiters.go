@@ -0,0 +1,319 @@
+package glesys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WaitOption configures how a waiter polls.
+type WaitOption func(*waitOptions)
+
+type waitOptions struct {
+	interval    time.Duration
+	maxInterval time.Duration
+	factor      float64
+	timeout     time.Duration
+}
+
+// WithPollInterval sets the delay between the first polls and the maximum
+// delay between polls. The delay grows after every poll until max is
+// reached, see WithBackoffFactor. Defaults to 2 and 30 seconds.
+func WithPollInterval(initial, max time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.interval = initial
+		o.maxInterval = max
+	}
+}
+
+// WithBackoffFactor sets the factor the delay between polls is multiplied by
+// after every poll. A factor of 1 polls at a fixed interval. Defaults to 2.
+func WithBackoffFactor(factor float64) WaitOption {
+	return func(o *waitOptions) {
+		o.factor = factor
+	}
+}
+
+// WithWaitTimeout limits how long a waiter waits, in addition to the
+// deadline of the context.
+func WithWaitTimeout(timeout time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.timeout = timeout
+	}
+}
+
+func newWaitOptions(opts []WaitOption) waitOptions {
+	o := waitOptions{interval: 2 * time.Second, maxInterval: 30 * time.Second, factor: 2}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.maxInterval < o.interval {
+		o.maxInterval = o.interval
+	}
+	if o.factor < 1 {
+		o.factor = 1
+	}
+	return o
+}
+
+// WaitError is returned by waiters when the condition is not met before the
+// context is done or the timeout expires. It matches the error of the
+// context using errors.Is.
+type WaitError struct {
+	// Resource describes the resource, e.g. "server kvm12345".
+	Resource string
+	// Condition describes the awaited condition, e.g. "running".
+	Condition string
+	// Attempts is the number of times the resource was fetched.
+	Attempts int
+	// Last is the last observed state of the resource, e.g. a
+	// *ServerDetails, or nil if it was never fetched.
+	Last interface{}
+	// LastErr is the error of the last attempt if it failed with an error
+	// accepted by Waiter.Retryable.
+	LastErr error
+	// Err is the error of the context.
+	Err error
+}
+
+func (e *WaitError) Error() string {
+	if e.LastErr != nil {
+		return fmt.Sprintf("glesys: %s not %s after %d attempts: %v (last error: %v)", e.Resource, e.Condition, e.Attempts, e.Err, e.LastErr)
+	}
+	return fmt.Sprintf("glesys: %s not %s after %d attempts: %v", e.Resource, e.Condition, e.Attempts, e.Err)
+}
+
+func (e *WaitError) Unwrap() error {
+	return e.Err
+}
+
+// Waiter polls a resource until a condition is met. It is used by the waiters
+// of the package, e.g. WaitUntilServerRunning, and can be used to wait for other conditions:
+//
+//	waiter := glesys.Waiter[*glesys.DatabaseDetails]{
+//		Resource:  "database " + id,
+//		Condition: "on plan " + plan,
+//		Fetch: func(ctx context.Context) (*glesys.DatabaseDetails, error) {
+//			return client.Databases.Details(ctx, id)
+//		},
+//		Ready: func(database *glesys.DatabaseDetails) bool {
+//			return database.Plan.Key == plan
+//		},
+//	}
+//	database, err := waiter.Wait(ctx, glesys.WithWaitTimeout(10*time.Minute))
+type Waiter[T any] struct {
+	// Resource describes the resource in errors, e.g. "server kvm12345".
+	Resource string
+	// Condition describes the awaited condition in errors, e.g. "running".
+	Condition string
+	// Fetch returns the current state of the resource.
+	Fetch func(ctx context.Context) (T, error)
+	// Ready reports whether the condition is met.
+	Ready func(T) bool
+	// Retryable reports whether polling continues after Fetch returned err,
+	// e.g. while a new resource is not found. By default polling stops on
+	// all errors.
+	Retryable func(err error) bool
+}
+
+// Wait polls the resource until the condition is met. The last observed state
+// is returned also when an error is returned. A *WaitError is returned if ctx
+// is done or the timeout expires before the condition is met, other errors
+// are returned as is.
+func (w *Waiter[T]) Wait(ctx context.Context, opts ...WaitOption) (T, error) {
+	o := newWaitOptions(opts)
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	var last T
+	var lastErr error
+	observed := false
+	waitError := func(attempts int, err error) error {
+		werr := &WaitError{Resource: w.Resource, Condition: w.Condition, Attempts: attempts, LastErr: lastErr, Err: err}
+		if observed {
+			werr.Last = last
+		}
+		return werr
+	}
+
+	interval := o.interval
+	for attempts := 1; ; attempts++ {
+		current, err := w.Fetch(ctx)
+		switch {
+		case err != nil && ctx.Err() != nil:
+			return last, waitError(attempts, ctx.Err())
+		case err != nil && (w.Retryable == nil || !w.Retryable(err)):
+			return last, err
+		case err != nil:
+			lastErr = err
+		default:
+			last, lastErr, observed = current, nil, true
+			if w.Ready(current) {
+				return current, nil
+			}
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return last, waitError(attempts, err)
+		}
+		interval = time.Duration(float64(interval) * o.factor)
+		if interval > o.maxInterval {
+			interval = o.maxInterval
+		}
+	}
+}
+
+// WaitUntilServer polls the server until condition returns true. The last
+// observed details are returned also when an error is returned. A *WaitError
+// is returned if ctx is done or the timeout expires before the condition is
+// met.
+func WaitUntilServer(ctx context.Context, servers ServerAPI, serverID string, condition func(*ServerDetails) bool, opts ...WaitOption) (*ServerDetails, error) {
+	return waitUntilServer(ctx, servers, serverID, "ready", condition, opts)
+}
+
+// WaitUntilServerRunning polls the server until it is running and not
+// locked, e.g. after Create or Start.
+func WaitUntilServerRunning(ctx context.Context, servers ServerAPI, serverID string, opts ...WaitOption) (*ServerDetails, error) {
+	return waitUntilServer(ctx, servers, serverID, "running", func(server *ServerDetails) bool {
+		return server.IsRunning && !server.IsLocked
+	}, opts)
+}
+
+// WaitUntilServerStopped polls the server until it is stopped and not
+// locked.
+func WaitUntilServerStopped(ctx context.Context, servers ServerAPI, serverID string, opts ...WaitOption) (*ServerDetails, error) {
+	return waitUntilServer(ctx, servers, serverID, "stopped", func(server *ServerDetails) bool {
+		return !server.IsRunning && !server.IsLocked
+	}, opts)
+}
+
+// WaitUntilServerUnlocked polls the server until it is not locked, e.g.
+// after Edit.
+func WaitUntilServerUnlocked(ctx context.Context, servers ServerAPI, serverID string, opts ...WaitOption) (*ServerDetails, error) {
+	return waitUntilServer(ctx, servers, serverID, "unlocked", func(server *ServerDetails) bool {
+		return !server.IsLocked
+	}, opts)
+}
+
+// WaitUntilServerState polls the server until its State is state, e.g.
+// "running".
+func WaitUntilServerState(ctx context.Context, servers ServerAPI, serverID string, state string, opts ...WaitOption) (*ServerDetails, error) {
+	return waitUntilServer(ctx, servers, serverID, "in state "+state, func(server *ServerDetails) bool {
+		return strings.EqualFold(server.State, state)
+	}, opts)
+}
+
+// WaitUntilServerIPAssigned polls the server until it has at least one IP
+// address.
+func WaitUntilServerIPAssigned(ctx context.Context, servers ServerAPI, serverID string, opts ...WaitOption) (*ServerDetails, error) {
+	return waitUntilServer(ctx, servers, serverID, "assigned an IP address", func(server *ServerDetails) bool {
+		for _, ip := range server.IPList {
+			if ip.Address != "" {
+				return true
+			}
+		}
+		return false
+	}, opts)
+}
+
+// WaitUntil polls the server until condition returns true, see
+// WaitUntilServer.
+func (s *ServerService) WaitUntil(ctx context.Context, serverID string, condition func(*ServerDetails) bool, opts ...WaitOption) (*ServerDetails, error) {
+	return WaitUntilServer(ctx, s, serverID, condition, opts...)
+}
+
+// WaitUntilRunning polls the server until it is running and not locked, see
+// WaitUntilServerRunning.
+func (s *ServerService) WaitUntilRunning(ctx context.Context, serverID string, opts ...WaitOption) (*ServerDetails, error) {
+	return WaitUntilServerRunning(ctx, s, serverID, opts...)
+}
+
+// WaitUntilStopped polls the server until it is stopped and not locked, see
+// WaitUntilServerStopped.
+func (s *ServerService) WaitUntilStopped(ctx context.Context, serverID string, opts ...WaitOption) (*ServerDetails, error) {
+	return WaitUntilServerStopped(ctx, s, serverID, opts...)
+}
+
+// WaitUntilUnlocked polls the server until it is not locked, see
+// WaitUntilServerUnlocked.
+func (s *ServerService) WaitUntilUnlocked(ctx context.Context, serverID string, opts ...WaitOption) (*ServerDetails, error) {
+	return WaitUntilServerUnlocked(ctx, s, serverID, opts...)
+}
+
+// WaitUntilState polls the server until its State is state, see
+// WaitUntilServerState.
+func (s *ServerService) WaitUntilState(ctx context.Context, serverID string, state string, opts ...WaitOption) (*ServerDetails, error) {
+	return WaitUntilServerState(ctx, s, serverID, state, opts...)
+}
+
+// WaitUntilIPAssigned polls the server until it has at least one IP address,
+// see WaitUntilServerIPAssigned.
+func (s *ServerService) WaitUntilIPAssigned(ctx context.Context, serverID string, opts ...WaitOption) (*ServerDetails, error) {
+	return WaitUntilServerIPAssigned(ctx, s, serverID, opts...)
+}
+
+func waitUntilServer(ctx context.Context, servers ServerAPI, serverID, description string, condition func(*ServerDetails) bool, opts []WaitOption) (*ServerDetails, error) {
+	waiter := Waiter[*ServerDetails]{
+		Resource:  "server " + serverID,
+		Condition: description,
+		Fetch: func(ctx context.Context) (*ServerDetails, error) {
+			return servers.Details(ctx, serverID)
+		},
+		Ready: condition,
+	}
+	return waiter.Wait(ctx, opts...)
+}
+
+// WaitUntilNetworkAdapterReady polls the network adapter until it is ready,
+// e.g. after Create or Edit.
+func WaitUntilNetworkAdapterReady(ctx context.Context, adapters NetworkAdapterAPI, networkAdapterID string, opts ...WaitOption) (*NetworkAdapter, error) {
+	waiter := Waiter[*NetworkAdapter]{
+		Resource:  "network adapter " + networkAdapterID,
+		Condition: "ready",
+		Fetch: func(ctx context.Context) (*NetworkAdapter, error) {
+			return adapters.Details(ctx, networkAdapterID)
+		},
+		Ready: (*NetworkAdapter).IsReady,
+	}
+	return waiter.Wait(ctx, opts...)
+}
+
+// WaitUntilDatabaseRunning polls the database until its status is running,
+// e.g. after Create.
+func WaitUntilDatabaseRunning(ctx context.Context, databases DatabaseAPI, databaseID string, opts ...WaitOption) (*DatabaseDetails, error) {
+	waiter := Waiter[*DatabaseDetails]{
+		Resource:  "database " + databaseID,
+		Condition: "running",
+		Fetch: func(ctx context.Context) (*DatabaseDetails, error) {
+			return databases.Details(ctx, databaseID)
+		},
+		Ready: func(database *DatabaseDetails) bool {
+			return strings.EqualFold(database.Status, "running") || strings.EqualFold(database.Status, "ready")
+		},
+	}
+	return waiter.Wait(ctx, opts...)
+}
+
+// WaitUntilObjectStorageInstanceAvailable polls the object storage instance
+// until its details can be fetched, e.g. after CreateInstance.
+func WaitUntilObjectStorageInstanceAvailable(ctx context.Context, storages ObjectStorageAPI, instanceID string, opts ...WaitOption) (*ObjectStorageInstance, error) {
+	waiter := Waiter[*ObjectStorageInstance]{
+		Resource:  "object storage instance " + instanceID,
+		Condition: "available",
+		Fetch: func(ctx context.Context) (*ObjectStorageInstance, error) {
+			return storages.InstanceDetails(ctx, instanceID)
+		},
+		Ready: func(instance *ObjectStorageInstance) bool {
+			return instance.InstanceID != ""
+		},
+		Retryable: func(err error) bool {
+			return errors.Is(err, ErrNotFound)
+		},
+	}
+	return waiter.Wait(ctx, opts...)
+}
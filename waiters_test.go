@@ -0,0 +1,229 @@
+package glesys
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sequenceClient returns the bodies in order, repeating the last one.
+type sequenceClient struct {
+	bodies []string
+	calls  int
+}
+
+func (c *sequenceClient) next(v interface{}) error {
+	body := c.bodies[min(c.calls, len(c.bodies)-1)]
+	c.calls++
+	if body == "error" {
+		return &APIError{StatusCode: 404, Text: "Not found"}
+	}
+	return json.Unmarshal([]byte(body), v)
+}
+
+func (c *sequenceClient) get(ctx context.Context, path string, v interface{}) error {
+	return c.next(v)
+}
+
+func (c *sequenceClient) post(ctx context.Context, path string, v interface{}, params interface{}) error {
+	return c.next(v)
+}
+
+const (
+	lockedServer  = `{ "response": { "server": { "serverid": "kvm12345", "state": "locked", "islocked": true } } }`
+	runningServer = `{ "response": { "server": { "serverid": "kvm12345", "state": "running", "isrunning": true, "iplist": [{ "ipaddress": "192.0.2.10" }] } } }`
+	stoppedServer = `{ "response": { "server": { "serverid": "kvm12345", "state": "stopped" } } }`
+)
+
+func TestWaitUntilServerRunning(t *testing.T) {
+	c := &sequenceClient{bodies: []string{lockedServer, lockedServer, runningServer}}
+	s := ServerService{client: c}
+
+	server, err := WaitUntilServerRunning(context.Background(), &s, "kvm12345", WithPollInterval(time.Millisecond, time.Millisecond))
+
+	assert.NoError(t, err)
+	assert.True(t, server.IsRunning)
+	assert.Equal(t, 3, c.calls, "server is polled until running")
+}
+
+func TestServerServiceWaiters(t *testing.T) {
+	ctx := context.Background()
+	poll := WithPollInterval(time.Millisecond, time.Millisecond)
+
+	c := &sequenceClient{bodies: []string{lockedServer, runningServer}}
+	s := ServerService{client: c}
+	server, err := s.WaitUntilRunning(ctx, "kvm12345", poll)
+	assert.NoError(t, err)
+	assert.True(t, server.IsRunning)
+	assert.Equal(t, 2, c.calls)
+
+	s = ServerService{client: &sequenceClient{bodies: []string{lockedServer, stoppedServer}}}
+	server, err = s.WaitUntilStopped(ctx, "kvm12345", poll)
+	assert.NoError(t, err)
+	assert.Equal(t, "stopped", server.State)
+
+	s = ServerService{client: &sequenceClient{bodies: []string{lockedServer, stoppedServer}}}
+	server, err = s.WaitUntilUnlocked(ctx, "kvm12345", poll)
+	assert.NoError(t, err)
+	assert.False(t, server.IsLocked)
+
+	s = ServerService{client: &sequenceClient{bodies: []string{lockedServer, runningServer}}}
+	server, err = s.WaitUntilState(ctx, "kvm12345", "running", poll)
+	assert.NoError(t, err)
+	assert.Equal(t, "running", server.State)
+
+	s = ServerService{client: &sequenceClient{bodies: []string{stoppedServer, runningServer}}}
+	server, err = s.WaitUntilIPAssigned(ctx, "kvm12345", poll)
+	assert.NoError(t, err)
+	assert.Len(t, server.IPList, 1)
+
+	s = ServerService{client: &sequenceClient{bodies: []string{stoppedServer, runningServer}}}
+	server, err = s.WaitUntil(ctx, "kvm12345", func(server *ServerDetails) bool { return server.IsRunning }, poll)
+	assert.NoError(t, err)
+	assert.True(t, server.IsRunning)
+}
+
+func TestWaitUntilServerConditions(t *testing.T) {
+	ctx := context.Background()
+	poll := WithPollInterval(time.Millisecond, time.Millisecond)
+
+	s := ServerService{client: &sequenceClient{bodies: []string{lockedServer, stoppedServer}}}
+	server, err := WaitUntilServerStopped(ctx, &s, "kvm12345", poll)
+	assert.NoError(t, err)
+	assert.Equal(t, "stopped", server.State)
+
+	s = ServerService{client: &sequenceClient{bodies: []string{lockedServer, stoppedServer}}}
+	server, err = WaitUntilServerUnlocked(ctx, &s, "kvm12345", poll)
+	assert.NoError(t, err)
+	assert.False(t, server.IsLocked)
+
+	s = ServerService{client: &sequenceClient{bodies: []string{lockedServer, stoppedServer, runningServer}}}
+	server, err = WaitUntilServerState(ctx, &s, "kvm12345", "RUNNING", poll)
+	assert.NoError(t, err, "state is compared case insensitively")
+	assert.Equal(t, "running", server.State)
+
+	s = ServerService{client: &sequenceClient{bodies: []string{stoppedServer, runningServer}}}
+	server, err = WaitUntilServerIPAssigned(ctx, &s, "kvm12345", poll)
+	assert.NoError(t, err)
+	assert.Equal(t, "192.0.2.10", server.IPList[0].Address)
+
+	s = ServerService{client: &sequenceClient{bodies: []string{lockedServer, runningServer}}}
+	server, err = WaitUntilServer(ctx, &s, "kvm12345", func(server *ServerDetails) bool { return server.State == "running" }, poll)
+	assert.NoError(t, err)
+	assert.Equal(t, "running", server.State)
+}
+
+func TestWaitUntilServerTimeout(t *testing.T) {
+	s := ServerService{client: &sequenceClient{bodies: []string{lockedServer}}}
+
+	server, err := WaitUntilServerRunning(context.Background(), &s, "kvm12345", WithPollInterval(time.Millisecond, 5*time.Millisecond), WithWaitTimeout(30*time.Millisecond))
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	var werr *WaitError
+	assert.True(t, errors.As(err, &werr))
+	assert.Equal(t, "server kvm12345", werr.Resource)
+	assert.Equal(t, "running", werr.Condition)
+	assert.Greater(t, werr.Attempts, 1)
+	assert.Equal(t, server, werr.Last, "last observed details are reported")
+	assert.Equal(t, "locked", server.State, "last observed details are returned")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = WaitUntilServerRunning(ctx, &s, "kvm12345")
+	assert.ErrorIs(t, err, context.Canceled, "context is honoured")
+}
+
+func TestWaitUntilServerError(t *testing.T) {
+	s := ServerService{client: &sequenceClient{bodies: []string{lockedServer, "error"}}}
+
+	server, err := WaitUntilServerRunning(context.Background(), &s, "kvm12345", WithPollInterval(time.Millisecond, time.Millisecond))
+
+	assert.ErrorIs(t, err, ErrNotFound, "errors are returned")
+	assert.Equal(t, "locked", server.State, "last observed details are returned")
+}
+
+func TestWaiter(t *testing.T) {
+	errTemporary := errors.New("temporary")
+	fetches := 0
+	waiter := Waiter[int]{
+		Resource:  "counter",
+		Condition: "at 3",
+		Fetch: func(ctx context.Context) (int, error) {
+			fetches++
+			if fetches == 2 {
+				return 0, errTemporary
+			}
+			return fetches, nil
+		},
+		Ready:     func(n int) bool { return n >= 3 },
+		Retryable: func(err error) bool { return err == errTemporary },
+	}
+
+	n, err := waiter.Wait(context.Background(), WithPollInterval(time.Millisecond, time.Millisecond), WithBackoffFactor(1))
+	assert.NoError(t, err, "retryable errors do not stop polling")
+	assert.Equal(t, 3, n)
+
+	waiter.Ready = func(n int) bool { return false }
+	waiter.Fetch = func(ctx context.Context) (int, error) { return 0, errTemporary }
+	_, err = waiter.Wait(context.Background(), WithPollInterval(time.Millisecond, time.Millisecond), WithWaitTimeout(10*time.Millisecond))
+	var werr *WaitError
+	assert.True(t, errors.As(err, &werr))
+	assert.Nil(t, werr.Last, "nothing was observed")
+	assert.Equal(t, errTemporary, werr.LastErr, "last error is reported")
+	assert.Contains(t, err.Error(), "glesys: counter not at 3 after")
+}
+
+func TestWaiterBackoff(t *testing.T) {
+	o := newWaitOptions(nil)
+	assert.Equal(t, 2*time.Second, o.interval, "default interval is correct")
+	assert.Equal(t, 30*time.Second, o.maxInterval, "default max interval is correct")
+	assert.Equal(t, 2.0, o.factor, "default factor is correct")
+
+	o = newWaitOptions([]WaitOption{WithPollInterval(time.Minute, time.Second), WithBackoffFactor(0.5)})
+	assert.Equal(t, time.Minute, o.maxInterval, "max interval is at least the interval")
+	assert.Equal(t, 1.0, o.factor, "delay never decreases")
+}
+
+func TestWaitUntilNetworkAdapterReady(t *testing.T) {
+	c := &sequenceClient{bodies: []string{
+		`{ "response": { "networkadapter": { "networkadapterid": "abc-123", "state": "locked" } } }`,
+		`{ "response": { "networkadapter": { "networkadapterid": "abc-123", "state": "ready" } } }`,
+	}}
+	s := NetworkAdapterService{client: c}
+
+	adapter, err := WaitUntilNetworkAdapterReady(context.Background(), &s, "abc-123", WithPollInterval(time.Millisecond, time.Millisecond))
+
+	assert.NoError(t, err)
+	assert.True(t, adapter.IsReady())
+	assert.Equal(t, 2, c.calls)
+}
+
+func TestWaitUntilDatabaseRunning(t *testing.T) {
+	c := &sequenceClient{bodies: []string{
+		`{ "response": { "database": { "id": "db-123", "status": "creating" } } }`,
+		`{ "response": { "database": { "id": "db-123", "status": "running" } } }`,
+	}}
+	s := DatabaseService{client: c}
+
+	database, err := WaitUntilDatabaseRunning(context.Background(), &s, "db-123", WithPollInterval(time.Millisecond, time.Millisecond))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "running", database.Status)
+}
+
+func TestWaitUntilObjectStorageInstanceAvailable(t *testing.T) {
+	c := &sequenceClient{bodies: []string{
+		"error",
+		`{ "response": { "instance": { "id": "os-123" } } }`,
+	}}
+	s := ObjectStorageService{client: c}
+
+	instance, err := WaitUntilObjectStorageInstanceAvailable(context.Background(), &s, "os-123", WithPollInterval(time.Millisecond, time.Millisecond))
+
+	assert.NoError(t, err, "not found is retried")
+	assert.Equal(t, "os-123", instance.InstanceID)
+}